@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultListen      = ":8080"
+	defaultPath        = "/graphql"
+	defaultDialTimeout = 10 * time.Second
+)
+
+var (
+	ErrMissingUpstreams = errors.New("at least one upstream is required")
+	ErrMissingAddress   = errors.New("upstream address is required")
+	ErrMergePolicy      = errors.New("merge policy must be append, first or last")
+	ErrCORSCredentials  = errors.New("cors credentials cannot be allowed for any origin")
+)
+
+// Config is the configuration of the edge server. Both YAML and JSON files
+// are accepted.
+type Config struct {
+	Listen    string     `yaml:"listen" json:"listen"`
+	Path      string     `yaml:"path" json:"path"`
+	GraphiQL  bool       `yaml:"graphiql" json:"graphiql"`
+	Pretty    bool       `yaml:"pretty" json:"pretty"`
+	CORS      *CORS      `yaml:"cors" json:"cors"`
+	Forward   *Forward   `yaml:"forward" json:"forward"`
+	Upstreams []Upstream `yaml:"upstreams" json:"upstreams"`
+}
+
+// Upstream is a gRPC server whose services are exposed by the edge server.
+// Its schema is read from DescriptorSet when set, otherwise using the gRPC
+// server reflection service. Timeout only bounds unary calls, the server
+// streams of subscriptions have no timeout.
+type Upstream struct {
+	Name          string        `yaml:"name" json:"name"`
+	Address       string        `yaml:"address" json:"address"`
+	TLS           *TLS          `yaml:"tls" json:"tls"`
+	DialTimeout   time.Duration `yaml:"dial_timeout" json:"dial_timeout"`
+	Timeout       time.Duration `yaml:"timeout" json:"timeout"`
+	DescriptorSet string        `yaml:"descriptor_set" json:"descriptor_set"`
+}
+
+type TLS struct {
+	CAFile             string `yaml:"ca_file" json:"ca_file"`
+	CertFile           string `yaml:"cert_file" json:"cert_file"`
+	KeyFile            string `yaml:"key_file" json:"key_file"`
+	ServerName         string `yaml:"server_name" json:"server_name"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify" json:"insecure_skip_verify"`
+}
+
+type CORS struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins" json:"allowed_origins"`
+	AllowedMethods   []string      `yaml:"allowed_methods" json:"allowed_methods"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" json:"allowed_headers"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" json:"exposed_headers"`
+	AllowCredentials bool          `yaml:"allow_credentials" json:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age" json:"max_age"`
+}
+
+// Forward lists the request headers and cookies forwarded to the upstreams as
+// gRPC metadata
+type Forward struct {
+	Headers []string          `yaml:"headers" json:"headers"`
+	Cookies []string          `yaml:"cookies" json:"cookies"`
+	Rename  map[string]string `yaml:"rename" json:"rename"`
+	// Response lists the metadata keys of the upstream responses copied into
+	// the HTTP response headers
+	Response *ForwardResponse `yaml:"response" json:"response"`
+}
+
+type ForwardResponse struct {
+	Keys     []string          `yaml:"keys" json:"keys"`
+	Rename   map[string]string `yaml:"rename" json:"rename"`
+	Policies map[string]string `yaml:"policies" json:"policies"`
+}
+
+// LoadConfig reads the configuration file at path. JSON being a subset of
+// YAML, both formats are parsed by the YAML decoder.
+func LoadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(b)
+}
+
+func ParseConfig(b []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Listen == "" {
+		cfg.Listen = defaultListen
+	}
+	if cfg.Path == "" {
+		cfg.Path = defaultPath
+	}
+	if len(cfg.Upstreams) == 0 {
+		return nil, ErrMissingUpstreams
+	}
+	if cfg.CORS != nil && cfg.CORS.AllowCredentials {
+		for _, o := range cfg.CORS.AllowedOrigins {
+			if o == "*" {
+				return nil, ErrCORSCredentials
+			}
+		}
+	}
+	if cfg.Forward != nil && cfg.Forward.Response != nil {
+		for key, policy := range cfg.Forward.Response.Policies {
+			if _, ok := mergePolicies[policy]; !ok {
+				return nil, fmt.Errorf("%w: forward.response.policies.%s", ErrMergePolicy, key)
+			}
+		}
+	}
+	for i := range cfg.Upstreams {
+		u := &cfg.Upstreams[i]
+		if u.Address == "" {
+			return nil, fmt.Errorf("%w: upstreams[%d]", ErrMissingAddress, i)
+		}
+		if u.Name == "" {
+			u.Name = u.Address
+		}
+		if u.DialTimeout <= 0 {
+			u.DialTimeout = defaultDialTimeout
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseConfig(t *testing.T) {
+	want := &Config{
+		Listen:   ":9000",
+		Path:     defaultPath,
+		GraphiQL: true,
+		CORS: &CORS{
+			AllowedOrigins: []string{"https://example.com"},
+			MaxAge:         10 * time.Minute,
+		},
+		Forward: &Forward{
+			Headers: []string{"Authorization"},
+			Cookies: []string{"session"},
+			Rename:  map[string]string{"session": "x-session"},
+			Response: &ForwardResponse{
+				Keys:     []string{"set-cookie", "cache-control"},
+				Policies: map[string]string{"cache-control": "first"},
+			},
+		},
+		Upstreams: []Upstream{
+			{
+				Name:        "hello",
+				Address:     "localhost:9090",
+				DialTimeout: defaultDialTimeout,
+				Timeout:     5 * time.Second,
+			},
+			{
+				Name:          "secure:443",
+				Address:       "secure:443",
+				TLS:           &TLS{ServerName: "secure"},
+				DialTimeout:   time.Second,
+				DescriptorSet: "secure.pb",
+			},
+		},
+	}
+	cases := map[string]string{
+		"yaml": `
+listen: ":9000"
+graphiql: true
+cors:
+  allowed_origins: ["https://example.com"]
+  max_age: 10m
+forward:
+  headers: [Authorization]
+  cookies: [session]
+  rename: {session: x-session}
+  response:
+    keys: [set-cookie, cache-control]
+    policies: {cache-control: first}
+upstreams:
+  - name: hello
+    address: localhost:9090
+    timeout: 5s
+  - address: secure:443
+    tls:
+      server_name: secure
+    dial_timeout: 1s
+    descriptor_set: secure.pb
+`,
+		"json": `{
+  "listen": ":9000",
+  "graphiql": true,
+  "cors": {"allowed_origins": ["https://example.com"], "max_age": "10m"},
+  "forward": {"headers": ["Authorization"], "cookies": ["session"], "rename": {"session": "x-session"},
+    "response": {"keys": ["set-cookie", "cache-control"], "policies": {"cache-control": "first"}}},
+  "upstreams": [
+    {"name": "hello", "address": "localhost:9090", "timeout": "5s"},
+    {"address": "secure:443", "tls": {"server_name": "secure"}, "dial_timeout": "1s", "descriptor_set": "secure.pb"}
+  ]
+}`,
+	}
+	for name, src := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := ParseConfig([]byte(src))
+			if err != nil {
+				t.Fatalf("failed to parse config: %s", err.Error())
+			}
+			if diff := cmp.Diff(want, cfg); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	if _, err := ParseConfig([]byte(`listen: ":9000"`)); !errors.Is(err, ErrMissingUpstreams) {
+		t.Errorf("expected %v, got %v", ErrMissingUpstreams, err)
+	}
+	if _, err := ParseConfig([]byte(`upstreams: [{name: hello}]`)); !errors.Is(err, ErrMissingAddress) {
+		t.Errorf("expected %v, got %v", ErrMissingAddress, err)
+	}
+	src := `{cors: {allowed_origins: ["*"], allow_credentials: true}, upstreams: [{address: hello}]}`
+	if _, err := ParseConfig([]byte(src)); !errors.Is(err, ErrCORSCredentials) {
+		t.Errorf("expected %v, got %v", ErrCORSCredentials, err)
+	}
+	src = `{forward: {response: {policies: {set-cookie: merge}}}, upstreams: [{address: hello}]}`
+	if _, err := ParseConfig([]byte(src)); !errors.Is(err, ErrMergePolicy) {
+		t.Errorf("expected %v, got %v", ErrMergePolicy, err)
+	}
+}
+
+func TestCORSHandler(t *testing.T) {
+	cors := &CORS{AllowedOrigins: []string{"https://example.com"}, MaxAge: time.Minute}
+	h := cors.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/graphql", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected preflight status %d, got %d", http.StatusNoContent, rec.Code)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Errorf("unexpected allowed origin: %q", got)
+	}
+	if got := rec.Header().Get("Access-Control-Max-Age"); got != "60" {
+		t.Errorf("unexpected max age: %q", got)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.Header.Set("Origin", "https://evil.com")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no allowed origin, got %q", got)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	edge "github.com/ncrypthic/graphql-grpc-edge/graphql"
+)
+
+// Forwarder returns the request metadata forwarder of the configuration, nil
+// when forwarding is not configured
+func (f *Forward) Forwarder() *edge.MetadataForwarder {
+	if f == nil {
+		return nil
+	}
+	return &edge.MetadataForwarder{
+		Headers: f.Headers,
+		Cookies: f.Cookies,
+		Rename:  f.Rename,
+	}
+}
+
+var mergePolicies = map[string]edge.MergePolicy{
+	"append": edge.MergeAppend,
+	"first":  edge.MergeFirst,
+	"last":   edge.MergeLast,
+}
+
+// ResponseForwarder returns the response metadata forwarder of the
+// configuration, nil when response forwarding is not configured
+func (f *Forward) ResponseForwarder() *edge.ResponseForwarder {
+	if f == nil || f.Response == nil {
+		return nil
+	}
+	policies := make(map[string]edge.MergePolicy, len(f.Response.Policies))
+	for key, policy := range f.Response.Policies {
+		policies[key] = mergePolicies[policy]
+	}
+	return &edge.ResponseForwarder{
+		Keys:     f.Response.Keys,
+		Rename:   f.Response.Rename,
+		Policies: policies,
+	}
+}
@@ -0,0 +1,104 @@
+// Command graphql-grpc-edge serves the graphql operations of gRPC upstream
+// services described by a configuration file, without generated code.
+//
+// Usage:
+//
+//	graphql-grpc-edge -config edge.yaml
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/handler"
+	edge "github.com/ncrypthic/graphql-grpc-edge/graphql"
+	"google.golang.org/grpc"
+)
+
+const (
+	shutdownTimeout = 10 * time.Second
+)
+
+func main() {
+	configPath := flag.String("config", "edge.yaml", "path to the YAML or JSON configuration file")
+	flag.Parse()
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("failed to load config, error: %v", err)
+	}
+	ctx := context.Background()
+	reg := edge.NewRegistry()
+	conns := make([]*grpc.ClientConn, 0, len(cfg.Upstreams))
+	for _, u := range cfg.Upstreams {
+		conn, err := u.Dial(ctx)
+		if err != nil {
+			log.Fatalf("failed to connect to upstream %s, error: %v", u.Name, err)
+		}
+		conns = append(conns, conn)
+		if err := u.Register(ctx, reg, conn); err != nil {
+			log.Fatalf("failed to register upstream %s, error: %v", u.Name, err)
+		}
+	}
+	schema, err := reg.Schema()
+	if err != nil {
+		log.Fatalf("failed to create new schema, error: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	h := cfg.Forward.ResponseForwarder().Handler(NewHandler(cfg, schema))
+	mux.Handle(cfg.Path, cfg.CORS.Handler(cfg.Forward.Forwarder().Handler(h)))
+	srv := &http.Server{Addr: cfg.Listen, Handler: mux}
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}()
+	log.Printf("serving graphql on %s%s", cfg.Listen, cfg.Path)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("failed to serve, error: %v", err)
+	}
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
+
+// NewHandler serves queries and mutations over HTTP, and subscriptions over
+// websocket or Server-Sent Events depending on the request
+func NewHandler(cfg *Config, schema *graphql.Schema) http.Handler {
+	h := handler.New(&handler.Config{
+		Schema:   schema,
+		Pretty:   cfg.Pretty,
+		GraphiQL: cfg.GraphiQL,
+	})
+	ws := edge.NewWebsocketHandler(schema)
+	if cfg.CORS != nil {
+		ws.Upgrader.CheckOrigin = func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			return origin == "" || cfg.CORS.allowOrigin(origin)
+		}
+	}
+	sse := edge.NewSSEHandler(schema)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case websocket.IsWebSocketUpgrade(r):
+			ws.ServeHTTP(w, r)
+		case strings.Contains(r.Header.Get("Accept"), "text/event-stream"):
+			sse.ServeHTTP(w, r)
+		default:
+			h.ServeHTTP(w, r)
+		}
+	})
+}
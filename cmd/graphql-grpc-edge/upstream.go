@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	edge "github.com/ncrypthic/graphql-grpc-edge/graphql"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Dial connects to the upstream, blocking until the connection is ready or
+// DialTimeout elapses
+func (u Upstream) Dial(ctx context.Context) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithBlock()}
+	if u.TLS != nil {
+		cfg, err := u.TLS.Config()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	if u.Timeout > 0 {
+		opts = append(opts, grpc.WithUnaryInterceptor(timeoutInterceptor(u.Timeout)))
+	}
+	ctx, cancel := context.WithTimeout(ctx, u.DialTimeout)
+	defer cancel()
+	return grpc.DialContext(ctx, u.Address, opts...)
+}
+
+// Register adds the services of the upstream to reg
+func (u Upstream) Register(ctx context.Context, reg *edge.Registry, conn *grpc.ClientConn) error {
+	if u.DescriptorSet == "" {
+		ctx, cancel := context.WithTimeout(ctx, u.DialTimeout)
+		defer cancel()
+		return reg.RegisterReflection(ctx, conn)
+	}
+	b, err := ioutil.ReadFile(u.DescriptorSet)
+	if err != nil {
+		return err
+	}
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, fds); err != nil {
+		return fmt.Errorf("invalid descriptor set %s: %w", u.DescriptorSet, err)
+	}
+	conns := make(map[string]grpc.ClientConnInterface)
+	for _, fd := range fds.GetFile() {
+		for _, sd := range fd.GetService() {
+			name := sd.GetName()
+			if fd.GetPackage() != "" {
+				name = fd.GetPackage() + "." + name
+			}
+			conns[name] = conn
+		}
+	}
+	return reg.RegisterDescriptorSet(fds, conns)
+}
+
+func (t TLS) Config() (*tls.Config, error) {
+	cfg := &tls.Config{
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+	if t.CAFile != "" {
+		b, err := ioutil.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no certificate found in %s", t.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// timeoutInterceptor bounds unary calls without a shorter deadline. Streams
+// are left alone since subscriptions are long lived.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
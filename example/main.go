@@ -46,14 +46,16 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to connect to grpc server: %v", err)
 	}
+	reg := edge.NewRegistry()
 	testClient := sample.NewHelloTestServiceClient(grpcClient)
-	sample.RegisterHelloTestServiceQueries(testClient)
+	sample.RegisterHelloTestServiceQueriesTo(reg, testClient)
 
 	helloClient := sample.NewHelloServiceClient(grpcClient)
-	sample.RegisterHelloServiceQueries(helloClient)
-	sample.RegisterHelloServiceMutations(helloClient)
+	sample.RegisterHelloServiceQueriesTo(reg, helloClient)
+	sample.RegisterHelloServiceMutationsTo(reg, helloClient)
+	sample.RegisterHelloServiceSubscriptionsTo(reg, helloClient)
 
-	schema, err := edge.GetSchema()
+	schema, err := reg.Schema()
 	if err != nil {
 		panic(err.Error())
 		log.Fatalf("failed to create new schema, error: %v", err)
@@ -69,6 +71,8 @@ func main() {
 		defer span.Finish()
 		h.ContextHandler(ctx, w, req)
 	})
+	http.Handle("/subscriptions", edge.NewWebsocketHandler(schema))
+	http.Handle("/stream", edge.NewSSEHandler(schema))
 	fmt.Printf("GraphQL gRPC edge server running on %s\n", HTTPPort)
 	http.ListenAndServe(HTTPPort, nil)
 }
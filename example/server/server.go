@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -27,6 +29,29 @@ func (h *HelloServer) Greeting(ctx context.Context, req *sample.HelloRequest) (*
 	}, nil
 }
 
+func (h *HelloServer) GreetingStream(req *sample.HelloRequest, stream sample.HelloService_GreetingStreamServer) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "GreetingStream")
+	defer span.Finish()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for i := 0; ; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		err := stream.Send(&sample.HelloResponse{
+			Data: &sample.Hello{
+				Name:     req.Name,
+				Messages: []string{fmt.Sprintf("greeting #%d", i)},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func (h *HelloServer) SetGreeting(ctx context.Context, req *sample.Hello) (*sample.HelloResponse, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "SetGreeting")
 	defer span.Finish()
@@ -0,0 +1,15 @@
+package generator
+
+import (
+	"github.com/ncrypthic/graphql-grpc-edge/graphql"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// description returns the graphql description of a declaration when the
+// descriptions are enabled
+func (v *visitor) description(option string, d protoreflect.Descriptor) string {
+	if !v.opts.Descriptions {
+		return ""
+	}
+	return graphql.Description(option, d)
+}
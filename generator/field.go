@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"github.com/ncrypthic/graphql-grpc-edge/graphql"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func fieldOption(p *protogen.Field) *graphql.GraphQLFieldOption {
+	return graphql.FieldOption(p.Desc)
+}
+
+// fieldName returns the graphql name of the field
+func fieldName(p *protogen.Field) string {
+	return graphql.FieldName(p.Desc)
+}
+
+// isSkipped reports whether the field is hidden from the object or input
+// type, either by its own option or by the option of its message or enum
+func isSkipped(p *protogen.Field, typ GQLType) bool {
+	if p.Message != nil && !p.Desc.IsMap() && isHidden(p.Message, typ) {
+		return true
+	}
+	return graphql.IsSkipped(p.Desc, typ == GQLTypeInput)
+}
+
+// isNonNull reports whether the field type is wrapped in a non null type,
+// either by its required option or by the nullability policy
+func (v *visitor) isNonNull(p *protogen.Field, typ GQLType) bool {
+	switch {
+	case fieldOption(p).GetRequired():
+		return true
+	case v.opts.Nullability != NullabilityProto:
+		return false
+	case p.Desc.Cardinality() == protoreflect.Required:
+		return true
+	case typ == GQLTypeInput || p.Desc.IsMap():
+		return false
+	case p.Desc.IsList():
+		return true
+	}
+	return p.Message == nil && !p.Desc.HasPresence()
+}
+
+// isNonNullElem reports whether the elements of a repeated field are non
+// null, which they always are in protobuf
+func (v *visitor) isNonNullElem(p *protogen.Field) bool {
+	return v.opts.Nullability == NullabilityProto && p.Desc.IsList()
+}
+
+func (v *visitor) fieldDescription(p *protogen.Field) string {
+	return v.description(fieldOption(p).GetDescription(), p.Desc)
+}
+
+func fieldDeprecation(p *protogen.Field) string {
+	return graphql.Deprecation(fieldOption(p).GetDeprecationReason(), p.Desc)
+}
+
+// visitFieldType prints the type of the field, wrapped in a list for
+// repeated fields and in non null types according to the nullability
+func (v *visitor) visitFieldType(p *protogen.Field, fieldType protogen.GoIdent, isList bool, gqlType GQLType) {
+	gqlNonNull := goIdent(graphqlImport, "NewNonNull")
+	typ := []interface{}{fieldType}
+	if isList {
+		if v.isNonNullElem(p) {
+			typ = []interface{}{gqlNonNull, "(", fieldType, ")"}
+		}
+		typ = append(append([]interface{}{goIdent(graphqlImport, "NewList"), "("}, typ...), ")")
+	}
+	if v.isNonNull(p, gqlType) {
+		typ = append(append([]interface{}{gqlNonNull, "("}, typ...), ")")
+	}
+	v.P(append(append([]interface{}{"Type: "}, typ...), ",")...)
+}
+
+// visitFieldDoc prints the description and the deprecation of the field
+func (v *visitor) visitFieldDoc(p *protogen.Field, typ GQLType) {
+	if desc := v.fieldDescription(p); desc != "" {
+		v.P("Description: ", quot(desc), ",")
+	}
+	if reason := fieldDeprecation(p); reason != "" && typ == GQLTypeObject {
+		v.P("DeprecationReason: ", quot(reason), ",")
+	}
+}
+
+// visitInputRenames declares the input fields whose graphql name differs from
+// their protobuf JSON name, so the arguments can be decoded. The members of a
+// oneof are fields of the input type of the oneof
+func (v *visitor) visitInputRenames(sym *Symbol) {
+	var fields []*protogen.Field
+	if sym.Message != nil {
+		fields = sym.Message.Fields
+	} else if sym.Oneof != nil {
+		fields = sym.Oneof.Fields
+	}
+	for _, f := range fields {
+		if isSkipped(f, GQLTypeInput) || fieldName(f) == f.Desc.JSONName() {
+			continue
+		}
+		if sym.Message != nil && isOneofMember(f) {
+			continue
+		}
+		v.P("reg.RenameInputField(", sym.Ident.String(), ", ", quot(fieldName(f)), ", ", quot(f.Desc.JSONName()), ")")
+	}
+}
+
+// oneofs returns the oneofs of the message, leaving out the synthetic oneofs
+// of proto3 optional fields
+func oneofs(m *protogen.Message) []*protogen.Oneof {
+	res := make([]*protogen.Oneof, 0)
+	for _, o := range m.Oneofs {
+		if !o.Desc.IsSynthetic() {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+func isOneofMember(p *protogen.Field) bool {
+	return graphql.IsOneofMember(p.Desc)
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ncrypthic/graphql-grpc-edge/graphql"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	NamingPlain   string = "plain"
+	NamingPackage        = "package"
+)
+
+var (
+	ErrNameCollision error = graphql.ErrNameCollision
+	ErrInvalidName         = graphql.ErrInvalidName
+)
+
+// typeName returns the graphql name of the type generated for a message or
+// an enum
+func (v *visitor) typeName(ident GQLIdent, d protoreflect.Descriptor) string {
+	name := graphql.NameOption(d)
+	if name == "" {
+		if v.opts.Naming == NamingGo {
+			return v.typeIdent(ident.GoIdent, d, ident.Type).GoName
+		}
+		name = graphql.BaseName(d, v.opts.Naming == NamingPackage)
+	}
+	if ident.Type == GQLTypeInput {
+		name += v.opts.InputSuffix
+	}
+	return name
+}
+
+// unionName returns the graphql name of the union or the input type
+// generated for a oneof
+func (v *visitor) unionName(o *protogen.Oneof, typ GQLType) string {
+	parent := GQLIdent{o.Parent.GoIdent, GQLTypeObject, v.GeneratedFile}
+	if v.opts.Naming == NamingGo && graphql.NameOption(o.Parent.Desc) == "" {
+		ident := GQLIdent{o.GoIdent, typ, v.GeneratedFile}
+		return ident.String()
+	}
+	name := v.typeName(parent, o.Parent.Desc) + graphql.PascalCase(string(o.Desc.Name()))
+	if typ == GQLTypeInput {
+		name += v.opts.InputSuffix
+	}
+	return name
+}
+
+// wrapperName returns the graphql name of the object type wrapping a oneof
+// member
+func (v *visitor) wrapperName(f *protogen.Field) string {
+	if v.opts.Naming == NamingGo && graphql.NameOption(f.Parent.Desc) == "" {
+		ident := GQLIdent{f.GoIdent, GQLTypeObject, v.GeneratedFile}
+		return ident.String()
+	}
+	return v.unionName(f.Oneof, GQLTypeObject) + graphql.PascalCase(string(f.Desc.Name()))
+}
+
+// owner describes the proto declaration a type symbol is generated for, an
+// empty string is returned for operation symbols
+func (s *Symbol) owner() string {
+	switch {
+	case s.Enum != nil:
+		return fmt.Sprintf("enum %s", s.Enum.Desc.FullName())
+	case s.Message != nil:
+		return fmt.Sprintf("%s %s", strings.ToLower(string(s.Ident.Type)), s.Message.Desc.FullName())
+	case s.Field != nil:
+		return fmt.Sprintf("oneof member %s", s.Field.Desc.FullName())
+	case s.Oneof != nil && s.Ident.Type == GQLTypeInput:
+		return fmt.Sprintf("input oneof %s", s.Oneof.Desc.FullName())
+	case s.Oneof != nil:
+		return fmt.Sprintf("oneof %s", s.Oneof.Desc.FullName())
+	}
+	return ""
+}
+
+// CheckNames fails when a generated type has an invalid graphql name, or
+// shares its name with another generated or built-in type
+func (t *SymbolTable) CheckNames() error {
+	owners := make(map[string]string)
+	problems := make([]string, 0)
+	var err error
+	for _, sym := range t.symbols {
+		owner := sym.owner()
+		if owner == "" {
+			continue
+		}
+		if !graphql.IsValidName(sym.Name) {
+			problems = append(problems, fmt.Sprintf("%q of %s", sym.Name, owner))
+			err = ErrInvalidName
+			continue
+		}
+		if _, ok := graphql.LookupType(sym.Name); ok || graphql.IsReservedName(sym.Name) {
+			owners[sym.Name] = "built-in type"
+		}
+		other, ok := owners[sym.Name]
+		if ok && other != owner {
+			pair := []string{other, owner}
+			sort.Strings(pair)
+			problems = append(problems, fmt.Sprintf("%q of %s and %s", sym.Name, pair[0], pair[1]))
+			if err == nil {
+				err = ErrNameCollision
+			}
+			continue
+		}
+		owners[sym.Name] = owner
+	}
+	if err != nil {
+		sort.Strings(problems)
+		return fmt.Errorf("%w: %s", err, strings.Join(problems, ", "))
+	}
+	return nil
+}
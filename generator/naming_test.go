@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestTypeName(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	messages := make(map[string]*protogen.Message)
+	for _, m := range f.Messages {
+		messages[m.GoIdent.GoName] = m
+	}
+	detail := messages["Test"].Messages[0]
+	cases := []struct {
+		naming  string
+		message *protogen.Message
+		typ     GQLType
+		want    string
+	}{
+		{NamingGo, messages["Test"], GQLTypeObject, "Object_Test"},
+		{NamingGo, messages["Test"], GQLTypeInput, "Input_Test"},
+		{NamingGo, messages["TestRenamed"], GQLTypeObject, "Renamed"},
+		{NamingGo, messages["TestRenamed"], GQLTypeInput, "RenamedInput"},
+		{NamingPlain, messages["Test"], GQLTypeObject, "Test"},
+		{NamingPlain, messages["Test"], GQLTypeInput, "TestInput"},
+		{NamingPlain, detail, GQLTypeObject, "TestTestDetail"},
+		{NamingPlain, messages["TestRenamed"], GQLTypeObject, "Renamed"},
+		{NamingPackage, messages["Test"], GQLTypeObject, "GeneratorTest"},
+		{NamingPackage, detail, GQLTypeInput, "GeneratorTestTestDetailInput"},
+		{NamingPackage, messages["TestRenamed"], GQLTypeInput, "RenamedInput"},
+	}
+	for _, c := range cases {
+		t.Run(c.naming+"/"+c.want, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Naming = c.naming
+			g := p.NewGeneratedFile("test_graphql.pb.go", f.GoImportPath)
+			tbl := NewSymbolTable(f)
+			v := NewVisitor(tbl, f, g, f.GoImportPath.String(), opts).(*visitor)
+			got := v.typeName(GQLIdent{c.message.GoIdent, c.typ, g}, c.message.Desc)
+			if got != c.want {
+				t.Errorf("expected %q, got %q", c.want, got)
+			}
+		})
+	}
+	opts := DefaultOptions()
+	opts.Naming = NamingPlain
+	g := p.NewGeneratedFile("test_graphql.pb.go", f.GoImportPath)
+	tbl := NewSymbolTable(f)
+	v := NewVisitor(tbl, f, g, f.GoImportPath.String(), opts).(*visitor)
+	if got := v.unionName(messages["Test"].Oneofs[0], GQLTypeObject); got != "TestError" {
+		t.Errorf("expected %q, got %q", "TestError", got)
+	}
+	if got := v.unionName(messages["Test"].Oneofs[0], GQLTypeInput); got != "TestErrorInput" {
+		t.Errorf("expected %q, got %q", "TestErrorInput", got)
+	}
+	if got := v.wrapperName(messages["TestMixedOneof"].Fields[0]); got != "TestMixedOneofValueText" {
+		t.Errorf("expected %q, got %q", "TestMixedOneofValueText", got)
+	}
+}
+
+func TestCheckNames(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	symbol := func(m *protogen.Message, typ GQLType, name string) *Symbol {
+		sym := NewSymbol(nil, GQLIdent{m.GoIdent, typ, nil})
+		sym.Message = m
+		sym.Name = name
+		return sym
+	}
+	cases := []struct {
+		name    string
+		symbols []*Symbol
+		wantErr error
+	}{
+		{
+			name: "unique",
+			symbols: []*Symbol{
+				symbol(f.Messages[0], GQLTypeObject, "Test"),
+				symbol(f.Messages[0], GQLTypeInput, "TestInput"),
+				symbol(f.Messages[1], GQLTypeObject, "TestScalar"),
+			},
+		},
+		{
+			name: "same type generated twice",
+			symbols: []*Symbol{
+				symbol(f.Messages[0], GQLTypeObject, "Test"),
+				symbol(f.Messages[0], GQLTypeObject, "Test"),
+			},
+		},
+		{
+			name: "collision",
+			symbols: []*Symbol{
+				symbol(f.Messages[0], GQLTypeObject, "Test"),
+				symbol(f.Messages[1], GQLTypeObject, "Test"),
+			},
+			wantErr: ErrNameCollision,
+		},
+		{
+			name: "object and input collision",
+			symbols: []*Symbol{
+				symbol(f.Messages[0], GQLTypeObject, "Test"),
+				symbol(f.Messages[0], GQLTypeInput, "Test"),
+			},
+			wantErr: ErrNameCollision,
+		},
+		{
+			name: "built-in collision",
+			symbols: []*Symbol{
+				symbol(f.Messages[0], GQLTypeObject, "Timestamp"),
+			},
+			wantErr: ErrNameCollision,
+		},
+		{
+			name: "invalid name",
+			symbols: []*Symbol{
+				symbol(f.Messages[0], GQLTypeObject, "Test-Object"),
+			},
+			wantErr: ErrInvalidName,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tbl := NewSymbolTable()
+			for _, sym := range c.symbols {
+				tbl.symbols = append(tbl.symbols, sym)
+			}
+			err := tbl.CheckNames()
+			if c.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if !errors.Is(err, c.wantErr) {
+				t.Errorf("expected %v, got %v", c.wantErr, err)
+			}
+		})
+	}
+}
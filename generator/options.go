@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ncrypthic/graphql-grpc-edge/graphql"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const (
+	NamingGo string = "go"
+
+	NullabilityNullable string = "nullable"
+	NullabilityProto           = "proto"
+)
+
+var (
+	ErrUnknownParameter error = errors.New("unknown parameter")
+	ErrInvalidParameter       = errors.New("invalid parameter")
+)
+
+// Options are the generator parameters, given to protoc with
+// `--graphql_opt=<name>=<value>`
+type Options struct {
+	// Suffix is appended to the file name prefix of the generated go files
+	Suffix string
+	// Naming is the graphql type naming strategy: `go` prefixes the go
+	// identifier with the kind of type, e.g. `Object_HelloResponse`; `plain`
+	// uses the PascalCase message name, e.g. `HelloResponse`; `package`
+	// qualifies it with the proto package, e.g. `SampleHelloResponse`
+	Naming string
+	// InputSuffix is appended to the input type names, unless the naming
+	// strategy is `go` which prefixes them with `Input_`
+	InputSuffix string
+	// Descriptions enables graphql descriptions in the generated types, taken
+	// from the description options or else the leading proto comments
+	Descriptions bool
+	// AllInputs emits input types for every message instead of only the
+	// messages used as rpc input
+	AllInputs bool
+	// Registry is the fully qualified go variable, such as
+	// `github.com/acme/edge.Registry`, of the *graphql.Registry the generated
+	// types are registered to. The default registry is used when empty.
+	Registry string
+	// SDL enables writing the graphql schema definition language files
+	SDL bool
+	// Nullability is the policy of non null field types: `nullable` only
+	// makes the fields with the required graphql option non null; `proto`
+	// also makes proto2 required fields, proto3 scalars without `optional`
+	// and repeated fields non null, as they always have a value
+	Nullability string
+	// FlattenArgs exposes the fields of rpc inputs as operation arguments
+	// instead of a single `input` argument, unless the graphql option of the
+	// rpc sets `flatten_args`
+	FlattenArgs bool
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Suffix:       "_graphql.pb.go",
+		Naming:       NamingGo,
+		InputSuffix:  "Input",
+		Descriptions: true,
+		Nullability:  NullabilityNullable,
+	}
+}
+
+// Flags binds the options to the plugin parameters
+func (o *Options) Flags() *flag.FlagSet {
+	flags := flag.NewFlagSet("protoc-gen-graphql", flag.ContinueOnError)
+	flags.StringVar(&o.Suffix, "suffix", o.Suffix, "generated go file name suffix")
+	flags.StringVar(&o.Naming, "naming", o.Naming, "graphql type naming strategy")
+	flags.StringVar(&o.InputSuffix, "input_suffix", o.InputSuffix, "graphql input type name suffix")
+	flags.BoolVar(&o.Descriptions, "descriptions", o.Descriptions, "emit graphql descriptions")
+	flags.BoolVar(&o.AllInputs, "all_inputs", o.AllInputs, "emit input types for every message")
+	flags.StringVar(&o.Registry, "registry", o.Registry, "registry variable the generated types are registered to")
+	flags.BoolVar(&o.SDL, "sdl", o.SDL, "also write the graphql schema definition language of each file and a merged schema.graphql")
+	flags.StringVar(&o.Nullability, "nullability", o.Nullability, "non null field types policy")
+	flags.BoolVar(&o.FlattenArgs, "flatten_args", o.FlattenArgs, "expose rpc input fields as operation arguments")
+	return flags
+}
+
+// ParamFunc returns a protogen.Options.ParamFunc setting the options, which
+// rejects unknown parameters
+func (o *Options) ParamFunc() func(name, value string) error {
+	flags := o.Flags()
+	return func(name, value string) error {
+		f := flags.Lookup(name)
+		if f == nil {
+			supported := make([]string, 0)
+			flags.VisitAll(func(f *flag.Flag) {
+				supported = append(supported, f.Name)
+			})
+			sort.Strings(supported)
+			return fmt.Errorf("%w %q, supported parameters are: %s", ErrUnknownParameter, name, strings.Join(supported, ", "))
+		}
+		if value == "" {
+			if _, ok := f.Value.(interface{ IsBoolFlag() bool }); ok {
+				value = "true"
+			}
+		}
+		if err := flags.Set(name, value); err != nil {
+			return fmt.Errorf("%w %s=%q: %s", ErrInvalidParameter, name, value, err.Error())
+		}
+		return nil
+	}
+}
+
+// Validate checks the combination of parameters
+func (o *Options) Validate() error {
+	if o.Suffix == "" || !strings.HasSuffix(o.Suffix, ".go") {
+		return fmt.Errorf("%w suffix=%q: must end with .go", ErrInvalidParameter, o.Suffix)
+	}
+	switch o.Naming {
+	case NamingGo, NamingPlain, NamingPackage:
+	default:
+		return fmt.Errorf("%w naming=%q: must be one of %q, %q or %q", ErrInvalidParameter, o.Naming, NamingGo, NamingPlain, NamingPackage)
+	}
+	switch o.Nullability {
+	case NullabilityNullable, NullabilityProto:
+	default:
+		return fmt.Errorf("%w nullability=%q: must be either %q or %q", ErrInvalidParameter, o.Nullability, NullabilityNullable, NullabilityProto)
+	}
+	if o.InputSuffix != "" && !graphql.IsValidName("_"+o.InputSuffix) {
+		return fmt.Errorf("%w input_suffix=%q: must only contain letters, digits or underscores", ErrInvalidParameter, o.InputSuffix)
+	}
+	if o.Registry != "" {
+		if _, ok := o.registryIdent(); !ok {
+			return fmt.Errorf("%w registry=%q: must be a fully qualified go variable such as github.com/acme/edge.Registry", ErrInvalidParameter, o.Registry)
+		}
+	}
+	return nil
+}
+
+func (o *Options) registryIdent() (protogen.GoIdent, bool) {
+	i := strings.LastIndex(o.Registry, ".")
+	if i <= 0 || i < strings.LastIndex(o.Registry, "/") || i == len(o.Registry)-1 {
+		return protogen.GoIdent{}, false
+	}
+	return goIdent(protogen.GoImportPath(o.Registry[:i]), o.Registry[i+1:]), true
+}
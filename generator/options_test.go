@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestOptions(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  [][2]string
+		want    Options
+		wantErr error
+	}{
+		{
+			name: "defaults",
+			want: DefaultOptions(),
+		},
+		{
+			name: "all parameters",
+			params: [][2]string{
+				{"suffix", ".graphql.go"},
+				{"naming", "plain"},
+				{"input_suffix", "Args"},
+				{"descriptions", "false"},
+				{"all_inputs", ""},
+				{"registry", "github.com/acme/edge.Registry"},
+				{"sdl", "true"},
+				{"nullability", "proto"},
+				{"flatten_args", "true"},
+			},
+			want: Options{
+				Suffix:      ".graphql.go",
+				Naming:      NamingPlain,
+				InputSuffix: "Args",
+				AllInputs:   true,
+				Registry:    "github.com/acme/edge.Registry",
+				SDL:         true,
+				Nullability: NullabilityProto,
+				FlattenArgs: true,
+			},
+		},
+		{
+			name:    "unknown parameter",
+			params:  [][2]string{{"sufix", "_graphql.go"}},
+			wantErr: ErrUnknownParameter,
+		},
+		{
+			name:    "invalid boolean",
+			params:  [][2]string{{"sdl", "yes please"}},
+			wantErr: ErrInvalidParameter,
+		},
+		{
+			name:    "invalid suffix",
+			params:  [][2]string{{"suffix", ".graphql"}},
+			wantErr: ErrInvalidParameter,
+		},
+		{
+			name:    "invalid naming",
+			params:  [][2]string{{"naming", "kebab"}},
+			wantErr: ErrInvalidParameter,
+		},
+		{
+			name:    "invalid input suffix",
+			params:  [][2]string{{"input_suffix", "-input"}},
+			wantErr: ErrInvalidParameter,
+		},
+		{
+			name:    "invalid nullability",
+			params:  [][2]string{{"nullability", "strict"}},
+			wantErr: ErrInvalidParameter,
+		},
+		{
+			name:    "invalid registry",
+			params:  [][2]string{{"registry", "github.com/acme/edge"}},
+			wantErr: ErrInvalidParameter,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			set := opts.ParamFunc()
+			var err error
+			for _, param := range c.params {
+				if err = set(param[0], param[1]); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				err = opts.Validate()
+			}
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) {
+					t.Fatalf("expected %v, got %v", c.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if diff := cmp.Diff(c.want, opts); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestOptionsRegistry(t *testing.T) {
+	opts := Options{Registry: "github.com/acme/edge.Registry"}
+	want := protogen.GoIdent{GoName: "Registry", GoImportPath: "github.com/acme/edge"}
+	if got, ok := opts.registryIdent(); !ok || got != want {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
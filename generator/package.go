@@ -1,28 +1,76 @@
 package generator
 
 import (
-	"flag"
+	"path"
+	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
 func Generate() {
-	var flags flag.FlagSet
+	opts := DefaultOptions()
 	protogen.Options{
-		ParamFunc: flags.Set,
+		ParamFunc: opts.ParamFunc(),
 	}.Run(func(gen *protogen.Plugin) error {
-		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
-		for _, f := range gen.Files {
-			if !f.Generate {
-				continue
-			}
-			filename := f.GeneratedFilenamePrefix + "_graphql.pb.go"
-			gen := gen.NewGeneratedFile(filename, f.GoImportPath)
-			v := NewVisitor(f, gen, f.GoImportPath.String())
+		return generate(gen, opts)
+	})
+}
 
-			v.Visit(root, f)
+// generate writes the files of a plugin run
+func generate(gen *protogen.Plugin, opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+	generated := make([]*protogen.File, 0)
+	for _, f := range gen.Files {
+		if f.Generate {
+			generated = append(generated, f)
 		}
-		return nil
-	})
+	}
+	tbl := NewSymbolTable(generated...)
+	schema := NewSDL()
+	var schemaFile *protogen.File
+	schemaDir := ""
+	for _, f := range generated {
+		filename := f.GeneratedFilenamePrefix + opts.Suffix
+		g := gen.NewGeneratedFile(filename, f.GoImportPath)
+		v := NewVisitor(tbl, f, g, f.GoImportPath.String(), opts)
+
+		v.Visit(tbl.Root(), f)
+		if opts.SDL {
+			s := NewSDL()
+			v.VisitSDL(s)
+			v.VisitSDL(schema)
+			gen.NewGeneratedFile(f.GeneratedFilenamePrefix+".graphql", f.GoImportPath).P(s.String())
+			if schemaFile == nil {
+				schemaFile = f
+				schemaDir = path.Dir(f.GeneratedFilenamePrefix)
+			} else {
+				schemaDir = commonDir(schemaDir, path.Dir(f.GeneratedFilenamePrefix))
+			}
+		}
+	}
+	if err := tbl.CheckNames(); err != nil {
+		return err
+	}
+	if schemaFile != nil {
+		filename := path.Join(schemaDir, "schema.graphql")
+		gen.NewGeneratedFile(filename, schemaFile.GoImportPath).P(schema.Schema())
+	}
+	return nil
+}
+
+// commonDir returns the deepest directory containing both a and b
+func commonDir(a, b string) string {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	i := 0
+	for i < len(as) && i < len(bs) && as[i] == bs[i] {
+		i++
+	}
+	if i == 0 {
+		return "."
+	}
+	return strings.Join(as[:i], "/")
 }
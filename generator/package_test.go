@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// generateFiles runs the generator over the proto files of testdata/multi,
+// generating the given files only
+func generateFiles(t *testing.T, generated ...string) map[string]string {
+	dir, err := ioutil.TempDir("", "multi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "multi.pb.descriptor")
+	cmd := exec.Command("protoc", "-o", out, "--include_imports", "-I", "../../", "-I", "testdata/multi", "shared.proto", "order.proto")
+	if b, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to generate protobuf descriptor: %s, %s", err.Error(), b)
+	}
+	b, err := ioutil.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, fds); err != nil {
+		t.Fatal(err)
+	}
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: generated,
+		ProtoFile:      fds.File,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generate(gen, DefaultOptions()); err != nil {
+		t.Fatal(err)
+	}
+	res := make(map[string]string)
+	for _, f := range gen.Response().File {
+		res[filepath.Base(f.GetName())] = strings.Join(strings.Fields(f.GetContent()), " ")
+	}
+	return res
+}
+
+func TestGenerateMultiPackage(t *testing.T) {
+	cases := []struct {
+		name      string
+		generated []string
+		want      map[string][]string
+		notWant   map[string][]string
+	}{
+		{
+			name:      "owner generated",
+			generated: []string{"shared.proto", "order.proto"},
+			want: map[string][]string{
+				"shared_graphql.pb.go": {
+					"var Enum_Currency *graphql.Enum",
+					"var Object_Money *graphql.Object",
+					"var Input_Money *graphql.InputObject",
+					"Value: Currency_name[1],",
+				},
+				"order_graphql.pb.go": {
+					"Type: shared.Object_Money,",
+					"Type: shared.Input_Money,",
+					"Type: shared.Enum_Currency,",
+					"func RegisterOrderServiceMutationsTo(reg *graphql1.Registry, sc OrderServiceClient) error { RegisterTypes_order_proto(reg) shared.RegisterTypes_shared_proto(reg)",
+				},
+			},
+			notWant: map[string][]string{
+				"order_graphql.pb.go": {"var Object_shared_Money", "var Input_shared_Money", "var Enum_shared_Currency"},
+			},
+		},
+		{
+			name:      "owner not generated",
+			generated: []string{"order.proto"},
+			want: map[string][]string{
+				"order_graphql.pb.go": {
+					"var Object_shared_Money *graphql.Object",
+					"var Input_shared_Money *graphql.InputObject",
+					"var Enum_shared_Currency *graphql.Enum",
+					"Value: shared.Currency_name[1],",
+					"Type: Object_shared_Money,",
+					"Type: Input_shared_Money,",
+					"Type: Enum_shared_Currency,",
+					"reg.RegisterType(Object_shared_Money)",
+					"func RegisterOrderServiceMutationsTo(reg *graphql1.Registry, sc OrderServiceClient) error { RegisterTypes_order_proto(reg) reg.RegisterMutation(",
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res := generateFiles(t, c.generated...)
+			for name, want := range c.want {
+				content, ok := res[name]
+				if !ok {
+					t.Fatalf("expected %s to be generated, got %v", name, keys(res))
+				}
+				for _, w := range want {
+					if !strings.Contains(content, w) {
+						t.Errorf("expected %q in %s:\n%s", w, name, content)
+					}
+				}
+			}
+			for name, notWant := range c.notWant {
+				for _, w := range notWant {
+					if strings.Contains(res[name], w) {
+						t.Errorf("unexpected %q in %s", w, name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func keys(m map[string]string) string {
+	res := make([]string, 0)
+	for k := range m {
+		res = append(res, k)
+	}
+	return fmt.Sprint(res)
+}
@@ -0,0 +1,403 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/ncrypthic/graphql-grpc-edge/graphql"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var sdlRoots = []struct {
+	Type GQLType
+	Name string
+	Op   string
+}{
+	{GQLTypeQuery, "RootQuery", "query"},
+	{GQLTypeMutation, "RootMutation", "mutation"},
+	{GQLTypeSubscription, "RootSubscription", "subscription"},
+}
+
+// SDL collects the graphql schema definition language of visited files
+type SDL struct {
+	definitions map[string]string
+	operations  map[GQLType]map[string]string
+	builtins    map[string]gql.Type
+	directives  map[string]string
+}
+
+func NewSDL() *SDL {
+	return &SDL{
+		definitions: make(map[string]string),
+		operations:  make(map[GQLType]map[string]string),
+		builtins:    make(map[string]gql.Type),
+		directives:  make(map[string]string),
+	}
+}
+
+func (s *SDL) define(name, definition string) {
+	s.definitions[name] = definition
+}
+
+func (s *SDL) operation(typ GQLType, name, field string) {
+	if _, ok := s.operations[typ]; !ok {
+		s.operations[typ] = make(map[string]string)
+	}
+	s.operations[typ][name] = field
+}
+
+// builtin records a type of the graphql-grpc-edge package referenced by the
+// definitions and returns its name
+func (s *SDL) builtin(t gql.Type) string {
+	s.builtins[t.Name()] = t
+	return t.Name()
+}
+
+// String renders the definitions followed by the root operation types
+func (s *SDL) String() string {
+	return strings.Join(s.blocks(), "\n\n")
+}
+
+// directive records a directive used by the definitions and returns its
+// usage
+func (s *SDL) directive(name, definition string) string {
+	s.directives[name] = definition
+	return "@" + name
+}
+
+// Schema renders a complete schema document, including the directives and
+// the types of the graphql-grpc-edge package referenced by the definitions
+func (s *SDL) Schema() string {
+	builtins := make([]string, 0)
+	for _, name := range sortedKeys(s.directives) {
+		builtins = append(builtins, s.directives[name])
+	}
+	types := make([]string, 0)
+	for _, t := range s.builtins {
+		types = append(types, sdlBuiltin(t))
+	}
+	sort.Strings(types)
+	builtins = append(builtins, types...)
+	blocks := append(builtins, s.blocks()...)
+	roots := make([]string, 0)
+	for _, root := range sdlRoots {
+		if len(s.operations[root.Type]) > 0 {
+			roots = append(roots, root.Op+": "+root.Name)
+		}
+	}
+	if len(roots) > 0 {
+		blocks = append(blocks, sdlBlock("schema", "", roots))
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+func (s *SDL) blocks() []string {
+	blocks := make([]string, 0)
+	for _, name := range sortedKeys(s.definitions) {
+		blocks = append(blocks, s.definitions[name])
+	}
+	for _, root := range sdlRoots {
+		ops := s.operations[root.Type]
+		if len(ops) == 0 {
+			continue
+		}
+		fields := make([]string, 0)
+		for _, name := range sortedKeys(ops) {
+			fields = append(fields, ops[name])
+		}
+		blocks = append(blocks, sdlBlock("type", root.Name, fields))
+	}
+	return blocks
+}
+
+// VisitSDL adds the definitions of the symbols declared by the visited file
+func (v *visitor) VisitSDL(s *SDL) {
+	for _, sym := range v.tbl.symbols {
+		if sym.File != v.File {
+			continue
+		}
+		switch sym.Ident.Type {
+		case GQLTypeEnum:
+			v.sdlEnum(s, sym)
+		case GQLTypeObject:
+			if sym.Field != nil {
+				v.sdlOneOfWrapper(s, sym)
+			} else {
+				v.sdlObject(s, sym)
+			}
+		case GQLTypeInput:
+			if sym.Oneof != nil {
+				v.sdlOneOfInput(s, sym)
+			} else {
+				v.sdlInput(s, sym)
+			}
+		case GQLTypeQuery, GQLTypeMutation, GQLTypeSubscription:
+			v.sdlOperation(s, sym)
+		}
+	}
+}
+
+func (v *visitor) sdlEnum(s *SDL, sym *Symbol) {
+	name := sym.Name
+	values := make([]string, 0)
+	for _, val := range sym.Enum.Values {
+		value := enumValueName(val)
+		if reason := enumValueDeprecation(val); reason != "" {
+			value += " @deprecated(reason: " + sdlString(reason) + ")"
+		}
+		if desc := v.enumValueDescription(val); desc != "" {
+			value = sdlString(desc) + "\n  " + value
+		}
+		values = append(values, value)
+	}
+	s.define(name, v.sdlDescribe(sym.Enum.Desc, sdlBlock("enum", name, values)))
+}
+
+func (v *visitor) sdlObject(s *SDL, sym *Symbol) {
+	name := sym.Name
+	fields := make([]string, 0)
+	for _, f := range sym.Message.Fields {
+		if isOneofMember(f) || isSkipped(f, GQLTypeObject) {
+			continue
+		}
+		fields = append(fields, v.sdlField(s, f, GQLTypeObject))
+	}
+	for _, o := range oneofs(sym.Message) {
+		union := v.unionName(o, GQLTypeObject)
+		members := make([]string, 0)
+		for _, f := range o.Fields {
+			if isSkipped(f, GQLTypeObject) {
+				continue
+			}
+			if isWrappedMember(f) {
+				members = append(members, v.wrapperName(f))
+			} else {
+				members = append(members, v.sdlNamedType(s, f, GQLTypeObject))
+			}
+		}
+		s.define(union, v.sdlDescribe(o.Desc, "union "+union+" = "+strings.Join(members, " | ")))
+		field := string(o.Desc.Name()) + ": " + union
+		if desc := v.typeDescription(o.Desc); desc != "" {
+			field = sdlString(desc) + "\n  " + field
+		}
+		fields = append(fields, field)
+	}
+	s.define(name, v.sdlDescribe(sym.Message.Desc, sdlBlock("type", name, fields)))
+}
+
+// sdlOneOfWrapper defines the object type wrapping the value of a oneof
+// member
+func (v *visitor) sdlOneOfWrapper(s *SDL, sym *Symbol) {
+	f := sym.Field
+	field := "value: " + v.sdlNamedType(s, f, GQLTypeObject)
+	if reason := fieldDeprecation(f); reason != "" {
+		field += " @deprecated(reason: " + sdlString(reason) + ")"
+	}
+	definition := sdlBlock("type", sym.Name, []string{field})
+	if desc := v.fieldDescription(f); desc != "" {
+		definition = sdlString(desc) + "\n" + definition
+	}
+	s.define(sym.Name, definition)
+}
+
+func (v *visitor) sdlInput(s *SDL, sym *Symbol) {
+	name := sym.Name
+	fields := v.sdlInputFields(s, sym.Message, "\n  ")
+	s.define(name, v.sdlDescribe(sym.Message.Desc, sdlBlock("input", name, fields)))
+}
+
+// sdlInputFields returns the fields of the input type of a message, which are
+// also the arguments of the operations flattening it
+func (v *visitor) sdlInputFields(s *SDL, m *protogen.Message, sep string) []string {
+	fields := make([]string, 0)
+	for _, f := range m.Fields {
+		if isOneofMember(f) || isSkipped(f, GQLTypeInput) {
+			continue
+		}
+		field := fieldName(f) + ": " + v.sdlFieldType(s, f, GQLTypeInput)
+		if desc := v.fieldDescription(f); desc != "" {
+			field = sdlString(desc) + sep + field
+		}
+		fields = append(fields, field)
+	}
+	for _, o := range oneofs(m) {
+		field := string(o.Desc.Name()) + ": " + v.unionName(o, GQLTypeInput)
+		if desc := v.typeDescription(o.Desc); desc != "" {
+			field = sdlString(desc) + sep + field
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// sdlOneOfInput defines the input type of a oneof with the oneOf directive,
+// exactly one of its fields must be set
+func (v *visitor) sdlOneOfInput(s *SDL, sym *Symbol) {
+	fields := make([]string, 0)
+	for _, f := range sym.Oneof.Fields {
+		if isSkipped(f, GQLTypeInput) {
+			continue
+		}
+		fields = append(fields, v.sdlField(s, f, GQLTypeInput))
+	}
+	oneOf := s.directive("oneOf", "directive @oneOf on INPUT_OBJECT")
+	s.define(sym.Name, v.sdlDescribe(sym.Oneof.Desc, sdlBlock("input", sym.Name+" "+oneOf, fields)))
+}
+
+func (v *visitor) sdlOperation(s *SDL, sym *Symbol) {
+	args := "input: " + v.sdlMessageType(s, sym.Method.Input, GQLTypeInput)
+	if v.flattensArgs(sym.Method) {
+		args = strings.Join(v.sdlInputFields(s, sym.Method.Input, " "), ", ")
+	}
+	output := v.sdlMessageType(s, sym.Method.Output, GQLTypeObject)
+	if fields, _ := responseFields(sym.Method.Output, methodOption(sym.Method).GetResponseField()); len(fields) > 0 {
+		output = v.sdlFieldType(s, fields[len(fields)-1], GQLTypeObject)
+	}
+	field := sym.Name + "(" + args + "): " + output
+	if args == "" {
+		field = sym.Name + ": " + output
+	}
+	if reason := methodDeprecation(sym.Method); reason != "" {
+		field += " @deprecated(reason: " + sdlString(reason) + ")"
+	}
+	if desc := v.typeDescription(sym.Method.Desc); desc != "" {
+		field = sdlString(desc) + "\n  " + field
+	}
+	s.operation(sym.Ident.Type, sym.Name, field)
+}
+
+func (v *visitor) sdlField(s *SDL, p *protogen.Field, typ GQLType) string {
+	field := fieldName(p) + ": " + v.sdlFieldType(s, p, typ)
+	if reason := fieldDeprecation(p); reason != "" && typ == GQLTypeObject {
+		field += " @deprecated(reason: " + sdlString(reason) + ")"
+	}
+	if desc := v.fieldDescription(p); desc != "" {
+		field = sdlString(desc) + "\n  " + field
+	}
+	return field
+}
+
+// sdlString quotes s as a graphql string, whose escapes differ from the go
+// ones: only the control characters, the quote and the backslash are escaped
+func sdlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// sdlDescribe prefixes the definition with the description of the type
+func (v *visitor) sdlDescribe(d protoreflect.Descriptor, definition string) string {
+	if desc := v.typeDescription(d); desc != "" {
+		return sdlString(desc) + "\n" + definition
+	}
+	return definition
+}
+
+func (v *visitor) sdlFieldType(s *SDL, p *protogen.Field, typ GQLType) string {
+	name := v.sdlNamedType(s, p, typ)
+	if p.Desc.IsList() {
+		if v.isNonNullElem(p) {
+			name += "!"
+		}
+		name = "[" + name + "]"
+	}
+	if v.isNonNull(p, typ) {
+		name += "!"
+	}
+	return name
+}
+
+func (v *visitor) sdlNamedType(s *SDL, p *protogen.Field, typ GQLType) string {
+	switch {
+	case p.Desc.IsMap():
+		return s.builtin(graphql.Scalar_JSON)
+	case p.Message != nil:
+		return v.sdlMessageType(s, p.Message, typ)
+	case p.Enum != nil:
+		return v.typeName(GQLIdent{p.Enum.GoIdent, GQLTypeEnum, v.GeneratedFile}, p.Enum.Desc)
+	case p.Desc.Kind() == protoreflect.BytesKind:
+		return s.builtin(graphql.Scalar_bytes)
+	default:
+		return v.getEdgeType(p.Desc.Kind(), p.GoIdent, p, typ).GoName
+	}
+}
+
+func (v *visitor) sdlMessageType(s *SDL, m *protogen.Message, typ GQLType) string {
+	if typ == GQLTypeInput {
+		if t, ok := graphql.WellKnownInput(m.Desc.FullName()); ok {
+			return s.builtin(t)
+		}
+	} else if t, ok := graphql.WellKnownOutput(m.Desc.FullName()); ok {
+		return s.builtin(t)
+	}
+	return v.typeName(GQLIdent{m.GoIdent, typ, v.GeneratedFile}, m.Desc)
+}
+
+func sdlBuiltin(t gql.Type) string {
+	fields := make([]string, 0)
+	switch t := t.(type) {
+	case *gql.Object:
+		defs := t.Fields()
+		for name, f := range defs {
+			fields = append(fields, name+": "+f.Type.String())
+		}
+		sort.Strings(fields)
+		return sdlBlock("type", t.Name(), fields)
+	case *gql.InputObject:
+		defs := t.Fields()
+		for name, f := range defs {
+			fields = append(fields, name+": "+f.Type.String())
+		}
+		sort.Strings(fields)
+		return sdlBlock("input", t.Name(), fields)
+	default:
+		return "scalar " + t.Name()
+	}
+}
+
+func sdlBlock(keyword, name string, lines []string) string {
+	head := keyword
+	if name != "" {
+		head += " " + name
+	}
+	if len(lines) == 0 {
+		return head
+	}
+	return head + " {\n  " + strings.Join(lines, "\n  ") + "\n}"
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0)
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestVisitSDL(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	importPath := f.GoImportPath
+	g := p.NewGeneratedFile("test_graphql.pb.go", importPath)
+	tbl := NewSymbolTable(f)
+	v := NewVisitor(tbl, f, g, importPath.String(), DefaultOptions())
+	v.Visit(tbl.Root(), f)
+	s := NewSDL()
+	v.VisitSDL(s)
+	cases := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{
+			name:   "file",
+			output: s.String(),
+			want: []string{
+				"enum Enum_UserStatus {\n  UNKNOWN_USER_STATE\n  REGISTERED\n  UNREGISTERED\n}",
+				"type Object_TestRepeated {\n  tags: [String]\n  failedAttempts: [Timestamp]\n  history: [Enum_UserStatus]\n}",
+				"union Object_Test_Error = Object_Test_TestClientError | Object_Test_TestServerError",
+				"input Input_Test_TestDetail {\n  name: String\n  photo: bytes\n}",
+				"type RootQuery {\n  \"describe returns the item as is\"\n  describe(input: Input_TestDescribed): Object_TestDescribed\n  flattenFields(\"identifier\" id: String!, secret: String, newName: String, tags: [String]!, userStatus: Enum_UserStatus): Object_TestFieldOptions\n  hello(input: Input_Test): Object_Test\n  keepInput(input: RenamedInput): Renamed\n  legacy(input: Input_TestDeprecated): Object_TestDeprecated @deprecated(reason: \"No longer supported\")\n  unwrapData(input: Input_Test): Object_Test\n  unwrapNested(input: Input_Test): Object_Test_TestDetail\n}",
+				"type RootMutation {\n  flattenOneof(id: String, \"target of the request\" target: Input_TestOneOfInput_Target): Renamed\n  mutateHello(input: Input_Test): Empty\n  unwrapItems(input: Input_Test): [Object_Test]\n}",
+				"type RootSubscription {\n  helloStream(input: Input_Test): Object_Test\n  unwrapStatus(input: Input_Test): Enum_UserStatus\n}",
+				"\"severity level\"\nenum Level {\n  \"lowest level\"\n  low\n  \"highest level\"\n  LEVEL_HIGH @deprecated(reason: \"use low\")\n}",
+				"\"TestDescribed is documented by its comments,\\nwhich span two lines.\"\ntype Object_TestDescribed {\n  \"name of the item\"\n  name: String\n  \"explicit title\"\n  title: String\n  \"kind of the item\"\n  kind: Object_TestDescribed_Kind\n}",
+				"type Object_TestDeprecated {\n  old: String @deprecated(reason: \"No longer supported\")\n  renamed: String @deprecated(reason: \"use name\")\n  kind: Enum_TestDeprecatedEnum\n}",
+				"enum Enum_TestDeprecatedEnum {\n  DEPRECATED_UNKNOWN\n  DEPRECATED_OLD @deprecated(reason: \"No longer supported\")\n}",
+				"input Input_TestDeprecated {\n  old: String\n  renamed: String\n  kind: Enum_TestDeprecatedEnum\n}",
+				"\"message options\"\ntype Object_TestMessageOptions {\n  level: Level\n  outputOnly: Object_TestOutputOnly\n}",
+				"union Object_TestMixedOneof_Value = Object_TestMixedOneof_Text | Object_TestMixedOneof_Number | Object_TestMixedOneof_Status | Object_Test_TestDetail | Object_TestMixedOneof_At",
+				"\"numeric value\"\ntype Object_TestMixedOneof_Number {\n  value: Int\n}",
+				"type Object_TestMixedOneof_Status {\n  value: Enum_UserStatus\n}",
+				"input Input_Test_Error @oneOf {\n  client: Input_Test_TestClientError\n  server: Input_Test_TestServerError\n}",
+			},
+		},
+		{
+			name:   "schema",
+			output: s.Schema(),
+			want: []string{
+				"directive @oneOf on INPUT_OBJECT",
+				"scalar Timestamp",
+				"type StringValue {\n  value: String\n}",
+				"input StringValueInput {\n  value: String\n}",
+				"schema {\n  query: RootQuery\n  mutation: RootMutation\n  subscription: RootSubscription\n}",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, want := range c.want {
+				if !strings.Contains(c.output, want) {
+					t.Errorf("expected %q in:\n%s", want, c.output)
+				}
+			}
+		})
+	}
+	for _, hidden := range []string{"TestSkipped", "TestHiddenEnum", "Object_TestInputOnly"} {
+		if strings.Contains(s.String(), hidden) {
+			t.Errorf("expected %s to be left out of the definitions", hidden)
+		}
+	}
+	if strings.Contains(s.String(), "scalar Timestamp") {
+		t.Error("expected builtin definitions to be left out of the file definitions")
+	}
+}
+
+func TestSDLString(t *testing.T) {
+	cases := []struct {
+		value string
+		want  string
+	}{
+		{"plain", `"plain"`},
+		{"say \"hi\"\\n", `"say \"hi\"\\n"`},
+		{"two\nlines\tand tab", `"two\nlines\tand tab"`},
+		{"a\x01b\a", `"a\u0001b\u0007"`},
+		{"caf\u00e9 \U0001F600", "\"caf\u00e9 \U0001F600\""},
+	}
+	for _, c := range cases {
+		got := sdlString(c.value)
+		if got != c.want {
+			t.Errorf("expected %s, got %s", c.want, got)
+			continue
+		}
+		doc, err := parser.Parse(parser.ParseParams{Source: got + "\nscalar X"})
+		if err != nil {
+			t.Errorf("failed to parse %s: %v", got, err)
+			continue
+		}
+		if desc := doc.Definitions[0].(*ast.ScalarDefinition).Description; desc == nil || desc.Value != c.value {
+			t.Errorf("expected %s to be parsed as %q", got, c.value)
+		}
+	}
+}
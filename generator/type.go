@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"github.com/ncrypthic/graphql-grpc-edge/graphql"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var ErrResponseField error = graphql.ErrResponseField
+
+// isHidden reports whether no object or input type is generated for the
+// message
+func isHidden(m *protogen.Message, typ GQLType) bool {
+	opt := graphql.MessageOption(m.Desc)
+	if opt.GetInputOnly() && opt.GetOutputOnly() {
+		panic("graphql input_only and output_only options are exclusive: " + string(m.Desc.FullName()))
+	}
+	return graphql.IsHidden(m.Desc, typ == GQLTypeInput)
+}
+
+func isEnumHidden(e *protogen.Enum) bool {
+	return graphql.EnumOption(e.Desc).GetSkip()
+}
+
+// typeDescription returns the description of a message, an enum, a oneof
+// or a method
+func (v *visitor) typeDescription(d protoreflect.Descriptor) string {
+	if !v.opts.Descriptions {
+		return ""
+	}
+	return graphql.TypeDescription(d)
+}
+
+// enumValueName returns the graphql name of the enum value
+func enumValueName(val *protogen.EnumValue) string {
+	return graphql.EnumValueName(val.Desc)
+}
+
+func (v *visitor) enumValueDescription(val *protogen.EnumValue) string {
+	return v.description(graphql.EnumValueOption(val.Desc).GetDescription(), val.Desc)
+}
+
+func enumValueDeprecation(val *protogen.EnumValue) string {
+	return graphql.Deprecation(graphql.EnumValueOption(val.Desc).GetDeprecationReason(), val.Desc)
+}
+
+func (v *visitor) visitTypeDescription(d protoreflect.Descriptor) {
+	if desc := v.typeDescription(d); desc != "" {
+		v.P("Description: ", quot(desc), ",")
+	}
+}
+
+func methodOption(m *protogen.Method) *graphql.GraphQLOption {
+	return graphql.MethodOption(m.Desc)
+}
+
+func methodDeprecation(m *protogen.Method) string {
+	return graphql.Deprecation(methodOption(m).GetDeprecationReason(), m.Desc)
+}
+
+// hasOperation reports whether the rpc is exposed as a graphql operation
+func hasOperation(m *protogen.Method) bool {
+	// the invalid operations fail when the service is visited
+	_, name, _ := graphql.Operation(m.Desc)
+	return name != ""
+}
+
+// flattensArgs reports whether the fields of the rpc input are arguments of
+// the operation, according to the option of the rpc or else the parameter
+func (v *visitor) flattensArgs(m *protogen.Method) bool {
+	if isWellKnown(m.Input.Desc) {
+		return false
+	}
+	if opt := methodOption(m); opt.FlattenArgs != nil {
+		return opt.GetFlattenArgs()
+	}
+	return v.opts.FlattenArgs
+}
+
+// responseFields returns the fields along the response_field path of an rpc
+// output, the operation returns the value of the last one
+func responseFields(m *protogen.Message, path string) ([]*protogen.Field, error) {
+	fields, err := graphql.ResponseFields(m.Desc, path)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*protogen.Field, len(fields))
+	for i, fd := range fields {
+		for _, f := range m.Fields {
+			if f.Desc == fd {
+				res[i] = f
+			}
+		}
+		m = res[i].Message
+	}
+	return res, nil
+}
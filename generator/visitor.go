@@ -7,7 +7,6 @@ import (
 
 	"github.com/ncrypthic/graphql-grpc-edge/graphql"
 	"google.golang.org/protobuf/compiler/protogen"
-	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -15,12 +14,14 @@ const (
 	graphqlImport = "github.com/graphql-go/graphql"
 	edgeImport    = "github.com/ncrypthic/graphql-grpc-edge/graphql"
 
-	GQLTypeObject   GQLType = GQLType("Object")
-	GQLTypeInput            = GQLType("Input")
-	GQLTypeScalar           = GQLType("Scalar")
-	GQLTypeEnum             = GQLType("Enum")
-	GQLTypeQuery            = GQLType("Query")
-	GQLTypeMutation         = GQLType("Mutation")
+	GQLTypeObject       GQLType = GQLType("Object")
+	GQLTypeInput                = GQLType("Input")
+	GQLTypeScalar               = GQLType("Scalar")
+	GQLTypeEnum                 = GQLType("Enum")
+	GQLTypeQuery                = GQLType("Query")
+	GQLTypeMutation             = GQLType("Mutation")
+	GQLTypeSubscription         = GQLType("Subscription")
+	GQLTypeUnion                = GQLType("Union")
 )
 
 type GQLType string
@@ -38,6 +39,17 @@ func (g *GQLIdent) String() string {
 type Symbol struct {
 	Ident  GQLIdent
 	Parent *Symbol
+	// File is the file whose generated code declares the symbol
+	File    *protogen.File
+	Enum    *protogen.Enum
+	Message *protogen.Message
+	Oneof   *protogen.Oneof
+	// Field is set for the object types wrapping oneof members
+	Field *protogen.Field
+	// Method is set for query, mutation and subscription symbols
+	Method *protogen.Method
+	// Name is the graphql name of the type or operation
+	Name string
 }
 
 func NewSymbol(parent *Symbol, ident GQLIdent) *Symbol {
@@ -47,30 +59,118 @@ func NewSymbol(parent *Symbol, ident GQLIdent) *Symbol {
 	}
 }
 
+// SymbolTable holds the symbols declared by a generation run. The types of
+// a proto file generated by the run are owned by the file, other files refer
+// to them, while the types of the other proto files are declared by every
+// package referring to them.
 type SymbolTable struct {
 	symbols    []*Symbol
 	mapSymbols map[string]*Symbol
+	root       *Symbol
+	// owners are the files generated by the run, by path
+	owners map[string]*protogen.File
+	// inputs are the messages used as rpc input, directly or through their
+	// fields, whose input type is declared by their owner
+	inputs map[protoreflect.FullName]struct{}
 }
 
+// NewSymbolTable returns the symbol table of a run generating files
+func NewSymbolTable(files ...*protogen.File) *SymbolTable {
+	t := &SymbolTable{
+		symbols:    make([]*Symbol, 0),
+		mapSymbols: make(map[string]*Symbol),
+		root:       &Symbol{},
+		owners:     make(map[string]*protogen.File),
+		inputs:     make(map[protoreflect.FullName]struct{}),
+	}
+	for _, f := range files {
+		t.owners[f.Desc.Path()] = f
+	}
+	for _, f := range files {
+		for _, svc := range f.Services {
+			for _, rpc := range svc.Methods {
+				if hasOperation(rpc) {
+					t.requireInput(rpc.Input)
+				}
+			}
+		}
+	}
+	return t
+}
+
+// Root returns the parent of the top level symbols
+func (t *SymbolTable) Root() *Symbol {
+	return t.root
+}
+
+// Append adds a symbol, keyed by the go variable its file declares
 func (t *SymbolTable) Append(s *Symbol) {
 	t.symbols = append(t.symbols, s)
-	t.mapSymbols[s.Ident.String()] = s
+	t.mapSymbols[string(s.File.GoImportPath)+"."+s.Ident.String()] = s
 }
 
-func (t *SymbolTable) LookUp(ident GQLIdent) (*Symbol, bool) {
-	s, ok := t.mapSymbols[ident.String()]
+// LookUp returns the symbol of a go variable
+func (t *SymbolTable) LookUp(ident protogen.GoIdent) (*Symbol, bool) {
+	s, ok := t.mapSymbols[string(ident.GoImportPath)+"."+ident.GoName]
 	return s, ok
 }
 
-func (t *SymbolTable) Exist(ident GQLIdent) bool {
-	_, ok := t.mapSymbols[ident.String()]
+func (t *SymbolTable) Exist(ident protogen.GoIdent) bool {
+	_, ok := t.LookUp(ident)
 	return ok
 }
 
-var (
-	tbl  *SymbolTable = &SymbolTable{make([]*Symbol, 0), make(map[string]*Symbol)}
-	root *Symbol      = &Symbol{}
-)
+// owner returns the file generated by the run which declares the types of a
+// proto declaration
+func (t *SymbolTable) owner(d protoreflect.Descriptor) (*protogen.File, bool) {
+	f, ok := t.owners[d.ParentFile().Path()]
+	return f, ok
+}
+
+// typeFiles returns the files generated by the run among f and its
+// transitive imports, whose types can be used by the declarations of f
+func (t *SymbolTable) typeFiles(f *protogen.File) []*protogen.File {
+	res := make([]*protogen.File, 0)
+	seen := make(map[string]struct{})
+	var visit func(d protoreflect.FileDescriptor)
+	visit = func(d protoreflect.FileDescriptor) {
+		if _, ok := seen[d.Path()]; ok {
+			return
+		}
+		seen[d.Path()] = struct{}{}
+		if owner, ok := t.owners[d.Path()]; ok {
+			res = append(res, owner)
+		}
+		imports := d.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			visit(imports.Get(i).FileDescriptor)
+		}
+	}
+	visit(f.Desc)
+	return res
+}
+
+func (t *SymbolTable) requireInput(m *protogen.Message) {
+	if _, ok := t.inputs[m.Desc.FullName()]; ok || isHidden(m, GQLTypeInput) || isWellKnown(m.Desc) {
+		return
+	}
+	t.inputs[m.Desc.FullName()] = struct{}{}
+	for _, nested := range m.Messages {
+		if !nested.Desc.IsMapEntry() {
+			t.requireInput(nested)
+		}
+	}
+	for _, f := range m.Fields {
+		if f.Message != nil && !f.Desc.IsMap() && !isSkipped(f, GQLTypeInput) {
+			t.requireInput(f.Message)
+		}
+	}
+}
+
+func (t *SymbolTable) isInput(m *protogen.Message) bool {
+	_, ok := t.inputs[m.Desc.FullName()]
+	return ok
+}
 
 type Printer interface {
 	Enter()
@@ -86,6 +186,7 @@ type Visitor interface {
 	VisitField(symbol *Symbol, p *protogen.Field, typ GQLType)
 	VisitOneOf(symbol *Symbol, p *protogen.Oneof, typ GQLType)
 	VisitService(symbol *Symbol, p *protogen.Service)
+	VisitSDL(s *SDL)
 }
 
 type visitor struct {
@@ -93,10 +194,12 @@ type visitor struct {
 	*protogen.File
 	indent []string
 	root   *Symbol
+	tbl    *SymbolTable
+	opts   Options
 }
 
-func NewVisitor(f *protogen.File, g *protogen.GeneratedFile, importPath string) Visitor {
-	v := &visitor{g, f, make([]string, 0), &Symbol{}}
+func NewVisitor(tbl *SymbolTable, f *protogen.File, g *protogen.GeneratedFile, importPath string, opts Options) Visitor {
+	v := &visitor{g, f, make([]string, 0), tbl.Root(), tbl, opts}
 	pkgName := strings.ReplaceAll(filepath.Base(importPath), "\"", "")
 	v.P("package ", pkgName)
 
@@ -119,50 +222,107 @@ func (v *visitor) P(data ...interface{}) Printer {
 }
 
 func (v *visitor) Visit(root *Symbol, p *protogen.File) {
-	registerType := goIdent(edgeImport, "RegisterType")
 	for _, enum := range p.Enums {
 		v.VisitEnum(root, enum)
 	}
 	for _, msg := range p.Messages {
 		v.VisitMessage(root, msg, GQLTypeObject)
 	}
+	for _, msg := range allMessages(p.Messages) {
+		if v.opts.AllInputs || v.tbl.isInput(msg) {
+			v.VisitMessage(root, msg, GQLTypeInput)
+		}
+	}
 	for _, svc := range p.Services {
 		v.VisitService(root, svc)
 	}
-	v.P("func init() {")
+	edgeRegistry := goIdent(edgeImport, "Registry")
+	v.P("")
+	v.P("func ", typesFunc(v.File).GoName, "(reg *", edgeRegistry, ") {")
 	v.Enter()
-	for _, sym := range tbl.mapSymbols {
-		if sym.Ident.GoImportPath != v.GoImportPath {
+	for _, sym := range v.tbl.symbols {
+		// the types are registered after the init functions of the file
+		// constructed them
+		if sym.File != v.File {
 			continue
 		}
 		switch sym.Ident.Type {
-		case GQLTypeEnum:
-			fallthrough
+		case GQLTypeEnum, GQLTypeObject:
+			v.P("reg.RegisterType(", sym.Ident.String(), ")")
 		case GQLTypeInput:
-			fallthrough
-		case GQLTypeObject:
-			v.P(registerType, "(", sym.Ident.String(), ")")
+			v.P("reg.RegisterType(", sym.Ident.String(), ")")
+			if sym.Oneof != nil {
+				v.P("reg.OneOfInput(", sym.Ident.String(), ")")
+			}
+			v.visitInputRenames(sym)
 		}
 	}
 	v.Exit()
 	v.P("}")
+	v.P("")
+	v.P("func init() {")
+	v.Enter()
+	v.P(typesFunc(v.File).GoName, "(", v.defaultRegistry(), ")")
+	v.Exit()
+	v.P("}")
+}
+
+// typesFunc returns the function registering the types declared by the
+// generated file of f
+func typesFunc(f *protogen.File) protogen.GoIdent {
+	return goIdent(f.GoImportPath, "RegisterTypes_"+strings.TrimPrefix(f.GoDescriptorIdent.GoName, "File_"))
+}
+
+// defaultRegistry returns the registry of init and of the registration
+// functions without a registry argument
+func (v *visitor) defaultRegistry() string {
+	if reg, ok := v.opts.registryIdent(); ok {
+		return v.QualifiedGoIdent(reg)
+	}
+	return v.QualifiedGoIdent(goIdent(edgeImport, "DefaultRegistry")) + "()"
 }
 
 func (v *visitor) VisitOneOf(root *Symbol, p *protogen.Oneof, typ GQLType) {
+	if typ == GQLTypeInput {
+		v.visitOneOfInput(root, p)
+		return
+	}
 	gqlUnion := goIdent(graphqlImport, "Union")
 	gqlNewUnion := goIdent(graphqlImport, "NewUnion")
 	gqlUnionConfig := goIdent(graphqlImport, "UnionConfig")
 	gqlObject := goIdent(graphqlImport, "Object")
-	v.P("var ", typ, "_", p.GoIdent, " *", gqlUnion, " = ", gqlNewUnion, "(", gqlUnionConfig, "{")
+	name := v.unionName(p, typ)
+	if typ == GQLTypeObject {
+		sym := NewSymbol(root, GQLIdent{p.GoIdent, GQLTypeUnion, v.GeneratedFile})
+		sym.File = v.File
+		sym.Oneof = p
+		sym.Name = name
+		v.tbl.Append(sym)
+	}
+	for _, f := range p.Fields {
+		if isWrappedMember(f) && !isSkipped(f, typ) {
+			v.visitOneOfWrapper(root, f)
+		}
+	}
+	union := GQLIdent{p.GoIdent, typ, v.GeneratedFile}
+	v.P("var ", union.String(), " *", gqlUnion, " = ", gqlNewUnion, "(", gqlUnionConfig, "{")
+	v.Enter()
+	v.P("Name: ", quot(name), ",")
+	v.visitTypeDescription(p.Desc)
+	v.P("Types: ", goIdent(graphqlImport, "UnionTypesThunk"), "(func() []*", gqlObject, " {")
 	v.Enter()
-	v.P("Name: ", `"`, typ, "_", p.GoIdent, `",`)
-	v.P("Types: []*", gqlObject, "{")
+	v.P("return []*", gqlObject, "{")
 	v.Enter()
 	for _, f := range p.Fields {
-		v.P(v.getEdgeType(f.Desc.Kind(), f.GoIdent, f, typ), ",")
+		if isSkipped(f, typ) {
+			continue
+		}
+		v.P(v.memberType(f), ",")
 	}
 	v.Exit()
-	v.P("},")
+	v.P("}")
+	v.Exit()
+	v.P("}),")
 	gqlResolveTypeParams := protogen.GoIdent{
 		GoName:       "ResolveTypeParams",
 		GoImportPath: graphqlImport,
@@ -171,9 +331,16 @@ func (v *visitor) VisitOneOf(root *Symbol, p *protogen.Oneof, typ GQLType) {
 	v.Enter()
 	v.P("switch p.Value.(type) {")
 	for _, f := range p.Fields {
-		v.P("case *", f.Message.GoIdent, ":")
+		if isSkipped(f, typ) {
+			continue
+		}
+		if isWrappedMember(f) {
+			v.P("case *", f.GoIdent, ":")
+		} else {
+			v.P("case *", f.Message.GoIdent, ":")
+		}
 		v.Enter()
-		v.P("return ", v.getEdgeType(f.Desc.Kind(), f.GoIdent, f, typ), "")
+		v.P("return ", v.memberType(f))
 		v.Exit()
 	}
 	v.P("default:")
@@ -187,11 +354,115 @@ func (v *visitor) VisitOneOf(root *Symbol, p *protogen.Oneof, typ GQLType) {
 	v.P("})")
 }
 
+// visitOneOfInput declares the input type of a oneof, whose members are
+// fields of which exactly one must be set
+func (v *visitor) visitOneOfInput(root *Symbol, p *protogen.Oneof) {
+	gqlInputObject := goIdent(graphqlImport, "InputObject")
+	gqlInputObjectConfigFieldMap := goIdent(graphqlImport, "InputObjectConfigFieldMap")
+	sym := NewSymbol(root, GQLIdent{p.GoIdent, GQLTypeInput, v.GeneratedFile})
+	sym.File = v.File
+	sym.Oneof = p
+	sym.Name = v.unionName(p, GQLTypeInput)
+	v.P("var ", sym.Ident.String(), " *", gqlInputObject)
+	v.P("")
+	v.P("func init() {")
+	v.Enter()
+	v.P(sym.Ident.String(), " = ", goIdent(graphqlImport, "NewInputObject"), "(")
+	v.Enter()
+	v.P(goIdent(graphqlImport, "InputObjectConfig"), "{")
+	v.Enter()
+	v.P("Name: ", quot(sym.Name), ",")
+	v.visitTypeDescription(p.Desc)
+	v.P("Fields: ", goIdent(graphqlImport, "InputObjectConfigFieldMapThunk"), "(func() ", gqlInputObjectConfigFieldMap, " {")
+	v.Enter()
+	v.P("return ", gqlInputObjectConfigFieldMap, "{")
+	v.Enter()
+	for _, f := range p.Fields {
+		v.VisitField(sym, f, GQLTypeInput)
+	}
+	v.Exit()
+	v.P("}")
+	v.Exit()
+	v.P("}),")
+	v.Exit()
+	v.P("},")
+	v.Exit()
+	v.P(")")
+	v.Exit()
+	v.P("}")
+	v.tbl.Append(sym)
+}
+
+// visitOneOfWrapper declares the object type holding the value of a oneof
+// member which is not an object, as graphql unions only have object members
+func (v *visitor) visitOneOfWrapper(root *Symbol, f *protogen.Field) {
+	gqlObject := goIdent(graphqlImport, "Object")
+	gqlResolveParams := goIdent(graphqlImport, "ResolveParams")
+	sym := NewSymbol(root, GQLIdent{f.GoIdent, GQLTypeObject, v.GeneratedFile})
+	sym.File = v.File
+	sym.Field = f
+	sym.Name = v.wrapperName(f)
+	v.P("var ", sym.Ident.String(), " *", gqlObject, " = ", goIdent(graphqlImport, "NewObject"), "(", goIdent(graphqlImport, "ObjectConfig"), "{")
+	v.Enter()
+	v.P("Name: ", quot(sym.Name), ",")
+	if desc := v.fieldDescription(f); desc != "" {
+		v.P("Description: ", quot(desc), ",")
+	}
+	v.P("Fields: ", goIdent(graphqlImport, "Fields"), "{")
+	v.Enter()
+	v.P(quot("value"), ": &", goIdent(graphqlImport, "Field"), "{")
+	v.Enter()
+	v.P("Type: ", v.getEdgeType(f.Desc.Kind(), f.GoIdent, f, GQLTypeObject), ",")
+	if reason := fieldDeprecation(f); reason != "" {
+		v.P("DeprecationReason: ", quot(reason), ",")
+	}
+	v.P("Resolve: func(p ", gqlResolveParams, ") (interface{}, error) {")
+	v.Enter()
+	v.P("if pdata, ok := p.Source.(*", f.GoIdent, "); ok {")
+	v.Enter()
+	if f.Enum != nil {
+		v.P("return pdata.", f.GoName, ".String(), nil")
+	} else {
+		v.P("return pdata.", f.GoName, ", nil")
+	}
+	v.Exit()
+	v.P("}")
+	v.P("return nil, nil")
+	v.Exit()
+	v.P("},")
+	v.Exit()
+	v.P("},")
+	v.Exit()
+	v.P("},")
+	v.Exit()
+	v.P("})")
+	v.tbl.Append(sym)
+}
+
+// memberType returns the object type of a oneof member in its union
+func (v *visitor) memberType(f *protogen.Field) protogen.GoIdent {
+	if isWrappedMember(f) {
+		ident := GQLIdent{f.GoIdent, GQLTypeObject, v.GeneratedFile}
+		return goIdent(v.GoImportPath, ident.String())
+	}
+	return v.getEdgeType(f.Desc.Kind(), f.GoIdent, f, GQLTypeObject)
+}
+
 func (v *visitor) VisitOneOfField(root *Symbol, o *protogen.Oneof, typ GQLType) {
+	if typ == GQLTypeInput {
+		v.P(quot(string(o.Desc.Name())), ": &", goIdent(graphqlImport, "InputObjectFieldConfig"), "{")
+		v.Enter()
+		v.P("Type: ", v.typeIdent(o.GoIdent, o.Parent.Desc, typ), ",")
+		v.visitTypeDescription(o.Desc)
+		v.Exit()
+		v.P("},")
+		return
+	}
 	gqlResolveParams := goIdent(graphqlImport, "ResolveParams")
 	v.P(quot(string(o.Desc.Name())), ": &", goIdent(graphqlImport, "Field{"))
 	v.Enter()
-	v.P("Type: ", typ, "_", o.GoIdent, ",")
+	v.P("Type: ", v.typeIdent(o.GoIdent, o.Parent.Desc, typ), ",")
+	v.visitTypeDescription(o.Desc)
 	v.P("Resolve: func(p ", gqlResolveParams, ") (interface{}, error) {")
 	v.Enter()
 	{
@@ -202,7 +473,9 @@ func (v *visitor) VisitOneOfField(root *Symbol, o *protogen.Oneof, typ GQLType)
 			for _, f := range o.Fields {
 				v.P("if d, ok := data.(*", f.GoIdent, "); ok {")
 				v.Enter()
-				{
+				if isWrappedMember(f) {
+					v.P("return d, nil")
+				} else {
 					v.P("return d.", f.GoName, ", nil")
 				}
 				v.Exit()
@@ -234,20 +507,19 @@ func (v *visitor) VisitField(symbol *Symbol, p *protogen.Field, typ GQLType) {
 }
 
 func (v *visitor) visitEnumField(symbol *Symbol, p *protogen.Field, typ GQLType, isList bool) {
+	if isSkipped(p, typ) {
+		return
+	}
 	fieldType := v.getEdgeType(p.Desc.Kind(), p.GoIdent, p, typ)
 	gqlField := goIdent(graphqlImport, "Field")
-	gqlList := goIdent(graphqlImport, "NewList")
 	gqlResolveParams := goIdent(graphqlImport, "ResolveParams")
 	if typ == GQLTypeInput {
 		gqlField = goIdent(graphqlImport, "InputObjectFieldConfig")
 	}
-	v.P(quot(p.Desc.JSONName()), ": &", gqlField, "{")
+	v.P(quot(fieldName(p)), ": &", gqlField, "{")
 	v.Enter()
-	if isList {
-		v.P("Type: ", gqlList, "(", fieldType, "),")
-	} else {
-		v.P("Type: ", fieldType, ",")
-	}
+	v.visitFieldType(p, fieldType, isList, typ)
+	v.visitFieldDoc(p, typ)
 	if typ == GQLTypeObject {
 		v.P("Resolve: func(p ", gqlResolveParams, ") (interface{}, error) {")
 		v.Enter()
@@ -269,61 +541,37 @@ func (v *visitor) visitEnumField(symbol *Symbol, p *protogen.Field, typ GQLType,
 	v.P("},")
 }
 
-func (v *visitor) visitOneOfField(symbol *Symbol, p *protogen.Field, typ GQLType, isList bool) {
-	fieldType := v.getEdgeType(p.Desc.Kind(), p.GoIdent, p, typ)
-	gqlField := goIdent(graphqlImport, "Field")
-	gqlList := goIdent(graphqlImport, "NewList")
-	gqlResolveParams := goIdent(graphqlImport, "ResolveParams")
-	if typ == GQLTypeInput {
-		gqlField = goIdent(graphqlImport, "InputObjectFieldConfig")
-	}
-	v.P(quot(p.Desc.JSONName()), ": &", gqlField, "{")
-	v.Enter()
-	if isList {
-		v.P("Type: ", gqlList, "(", fieldType, "),")
-	} else {
-		v.P("Type: ", fieldType, ",")
-	}
-	if typ == GQLTypeObject {
-		v.P("Resolve: func(p ", gqlResolveParams, ") (interface{}, error) {")
-		v.Enter()
-		{
-		}
-		v.Exit()
-		v.P("},")
-	}
-	v.Exit()
-	v.P("},")
-}
-
 func (v *visitor) visitMapField(symbol *Symbol, p *protogen.Field, typ GQLType) {
+	if isSkipped(p, typ) {
+		return
+	}
 	gqlField := goIdent(graphqlImport, "Field")
 	gqlJson := goIdent(edgeImport, "Scalar_JSON")
 	if typ == GQLTypeInput {
 		gqlField = goIdent(graphqlImport, "InputObjectFieldConfig")
 	}
-	v.P(quot(p.Desc.JSONName()), ": &", gqlField, "{")
+	v.P(quot(fieldName(p)), ": &", gqlField, "{")
 	v.Enter()
-	v.P("Type: ", gqlJson, ",")
+	v.visitFieldType(p, gqlJson, false, typ)
+	v.visitFieldDoc(p, typ)
 	v.Exit()
 	v.P("},")
 }
 
 func (v *visitor) visitGeneralField(symbol *Symbol, p *protogen.Field, typ GQLType, isList bool) {
+	if isSkipped(p, typ) {
+		return
+	}
 	fieldType := v.getEdgeType(p.Desc.Kind(), p.GoIdent, p, typ)
 	gqlField := goIdent(graphqlImport, "Field")
-	gqlList := goIdent(graphqlImport, "NewList")
 	gqlResolveParams := goIdent(graphqlImport, "ResolveParams")
 	if typ == GQLTypeInput {
 		gqlField = goIdent(graphqlImport, "InputObjectFieldConfig")
 	}
-	v.P(quot(p.Desc.JSONName()), ": &", gqlField, "{")
+	v.P(quot(fieldName(p)), ": &", gqlField, "{")
 	v.Enter()
-	if isList {
-		v.P("Type: ", gqlList, "(", fieldType, "),")
-	} else {
-		v.P("Type: ", fieldType, ",")
-	}
+	v.visitFieldType(p, fieldType, isList, typ)
+	v.visitFieldDoc(p, typ)
 	if typ == GQLTypeObject {
 		v.P("Resolve: func(p ", gqlResolveParams, ") (interface{}, error) {")
 		v.Enter()
@@ -351,7 +599,7 @@ func (v *visitor) VisitEnum(parent *Symbol, p *protogen.Enum) {
 		GQLTypeEnum,
 		v.GeneratedFile,
 	}
-	if tbl.Exist(ident) {
+	if isEnumHidden(p) || !v.declares(p.Desc) || v.tbl.Exist(v.typeIdent(p.GoIdent, p.Desc, GQLTypeEnum)) {
 		return
 	}
 	gqlEnum := goIdent(graphqlImport, "Enum")
@@ -360,17 +608,27 @@ func (v *visitor) VisitEnum(parent *Symbol, p *protogen.Enum) {
 	gqlEnumValueConfigMap := goIdent(graphqlImport, "EnumValueConfigMap")
 	gqlEnumValueConfig := goIdent(graphqlImport, "EnumValueConfig")
 	sym := NewSymbol(parent, ident)
+	sym.File = v.File
+	sym.Enum = p
+	sym.Name = v.typeName(ident, p.Desc)
 	v.P("var ", ident.String(), " *", gqlEnum, "= ", gqlNewEnum, "(")
 	v.Enter()
 	v.P(gqlEnumConfig, "{")
 	v.Enter()
-	v.P("Name: ", quot(ident.String()), ",")
+	v.P("Name: ", quot(sym.Name), ",")
+	v.visitTypeDescription(p.Desc)
 	v.P("Values: ", gqlEnumValueConfigMap, "{")
 	v.Enter()
 	for _, val := range p.Values {
-		v.P(quot(string(val.Desc.Name())), ": &", gqlEnumValueConfig, "{")
+		v.P(quot(enumValueName(val)), ": &", gqlEnumValueConfig, "{")
 		v.Enter()
-		v.P("Value: ", val.Parent.GoIdent.GoName, "_name[", val.Desc.Index(), "],")
+		v.P("Value: ", goIdent(p.GoIdent.GoImportPath, p.GoIdent.GoName+"_name"), "[", val.Desc.Index(), "],")
+		if desc := v.enumValueDescription(val); desc != "" {
+			v.P("Description: ", quot(desc), ",")
+		}
+		if reason := enumValueDeprecation(val); reason != "" {
+			v.P("DeprecationReason: ", quot(reason), ",")
+		}
 		v.Exit()
 		v.P("},")
 	}
@@ -380,7 +638,7 @@ func (v *visitor) VisitEnum(parent *Symbol, p *protogen.Enum) {
 	v.P("},")
 	v.Exit()
 	v.P(")")
-	tbl.Append(sym)
+	v.tbl.Append(sym)
 }
 
 func (v *visitor) VisitMessage(parent *Symbol, p *protogen.Message, typ GQLType) {
@@ -389,14 +647,20 @@ func (v *visitor) VisitMessage(parent *Symbol, p *protogen.Message, typ GQLType)
 		typ,
 		v.GeneratedFile,
 	}
-	if p.Desc.IsMapEntry() || tbl.Exist(ident) {
+	if p.Desc.IsMapEntry() || isWellKnown(p.Desc) || isHidden(p, typ) || !v.declares(p.Desc) {
+		return
+	}
+	if v.tbl.Exist(v.typeIdent(p.GoIdent, p.Desc, typ)) {
 		return
 	}
 	sym := NewSymbol(parent, ident)
+	sym.File = v.File
+	sym.Message = p
+	sym.Name = v.typeName(ident, p.Desc)
 	for _, e := range p.Enums {
 		v.VisitEnum(sym, e)
 	}
-	for _, o := range p.Oneofs {
+	for _, o := range oneofs(p) {
 		v.VisitOneOf(sym, o, typ)
 	}
 	for _, m := range p.Messages {
@@ -410,153 +674,290 @@ func (v *visitor) VisitMessage(parent *Symbol, p *protogen.Message, typ GQLType)
 	gqlObjectConfig := goIdent(graphqlImport, "ObjectConfig")
 	gqlIsTypeOfParams := goIdent(graphqlImport, "IsTypeOfParams")
 	gqlFields := goIdent(graphqlImport, "Fields")
+	gqlFieldsThunk := goIdent(graphqlImport, "FieldsThunk")
 	gqlInputObject := goIdent(graphqlImport, "InputObject")
 	gqlNewInputObject := goIdent(graphqlImport, "NewInputObject")
 	gqlInputObjectConfig := goIdent(graphqlImport, "InputObjectConfig")
 	gqlInputObjectConfigFieldMap := goIdent(graphqlImport, "InputObjectConfigFieldMap")
+	gqlInputObjectConfigFieldMapThunk := goIdent(graphqlImport, "InputObjectConfigFieldMapThunk")
 	switch typ {
 	case GQLTypeObject:
-		v.P("var ", sym.Ident.String(), " *", gqlObject, " = ", gqlNewObject, "(")
+		v.P("var ", sym.Ident.String(), " *", gqlObject)
+		v.P("")
+		v.P("func init() {")
+		v.Enter()
+		v.P(sym.Ident.String(), " = ", gqlNewObject, "(")
 		v.Enter()
 		v.P(gqlObjectConfig, "{")
 		v.Enter()
-		v.P("Name: ", quot(sym.Ident.String()), ",")
+		v.P("Name: ", quot(sym.Name), ",")
+		v.visitTypeDescription(p.Desc)
 		v.P("IsTypeOf: func(g ", gqlIsTypeOfParams, ") bool {")
 		v.Enter()
 		v.P("return true")
 		v.Exit()
 		v.P("},")
-		v.P("Fields: ", gqlFields, "{")
+		v.P("Fields: ", gqlFieldsThunk, "(func() ", gqlFields, " {")
+		v.Enter()
+		v.P("return ", gqlFields, "{")
 		v.Enter()
 		for _, f := range p.Fields {
-			if f.Oneof != nil {
+			if isOneofMember(f) {
 				continue
 			}
 			v.VisitField(sym, f, typ)
 		}
-		for _, o := range p.Oneofs {
-			v.VisitOneOfField(root, o, typ)
+		for _, o := range oneofs(p) {
+			v.VisitOneOfField(v.root, o, typ)
 		}
 		v.Exit()
-		v.P("},")
+		v.P("}")
+		v.Exit()
+		v.P("}),")
 		v.Exit()
 		v.P("},")
 		v.Exit()
 		v.P(")")
+		v.Exit()
+		v.P("}")
 	case GQLTypeInput:
-		v.P("var ", sym.Ident.String(), " *", gqlInputObject, " = ", gqlNewInputObject, "(")
+		v.P("var ", sym.Ident.String(), " *", gqlInputObject)
+		v.P("")
+		v.P("func init() {")
+		v.Enter()
+		v.P(sym.Ident.String(), " = ", gqlNewInputObject, "(")
 		v.Enter()
 		v.P(gqlInputObjectConfig, "{")
 		v.Enter()
-		v.P("Name: ", quot(sym.Ident.String()), ",")
-		v.P("Fields: ", gqlInputObjectConfigFieldMap, "{")
+		v.P("Name: ", quot(sym.Name), ",")
+		v.visitTypeDescription(p.Desc)
+		v.P("Fields: ", gqlInputObjectConfigFieldMapThunk, "(func() ", gqlInputObjectConfigFieldMap, " {")
+		v.Enter()
+		v.P("return ", gqlInputObjectConfigFieldMap, "{")
 		v.Enter()
 		for _, f := range p.Fields {
+			if isOneofMember(f) {
+				continue
+			}
 			v.VisitField(sym, f, typ)
 		}
+		for _, o := range oneofs(p) {
+			v.VisitOneOfField(v.root, o, typ)
+		}
 		v.Exit()
-		v.P("},")
+		v.P("}")
+		v.Exit()
+		v.P("}),")
 		v.Exit()
 		v.P("},")
 		v.Exit()
 		v.P(")")
+		v.Exit()
+		v.P("}")
 	default:
 	}
-	tbl.Append(sym)
+	v.tbl.Append(sym)
 	for _, f := range p.Fields {
 		if f.Message != nil {
-			v.VisitMessage(root, f.Message, typ)
+			v.VisitMessage(v.root, f.Message, typ)
+		}
+		if f.Enum != nil {
+			v.VisitEnum(v.root, f.Enum)
 		}
 	}
 }
 
+// operation is a graphql query, mutation or subscription served by a rpc
+type operation struct {
+	Name   string
+	Method *protogen.Method
+}
+
 func (v *visitor) VisitService(symbol *Symbol, p *protogen.Service) {
-	queries := make(map[string]*protogen.Method)
-	mutations := make(map[string]*protogen.Method)
-	inputs := make(map[*protogen.Message]struct{})
+	queries := make([]operation, 0)
+	mutations := make([]operation, 0)
+	subscriptions := make([]operation, 0)
+	inputs := make([]*protogen.Message, 0)
+	seen := make(map[*protogen.Message]struct{})
 	for _, rpc := range p.Methods {
-		edgeOpt := proto.GetExtension(rpc.Desc.Options(), graphql.E_Type)
-		if edgeOpt == nil {
-			continue
+		typ, name, err := graphql.Operation(rpc.Desc)
+		if err != nil {
+			panic(err.Error())
 		}
-		opt, ok := edgeOpt.(*graphql.GraphQLOption)
-		if !ok {
+		switch GQLType(typ) {
+		case GQLTypeQuery:
+			queries = append(queries, operation{name, rpc})
+		case GQLTypeMutation:
+			mutations = append(mutations, operation{name, rpc})
+		case GQLTypeSubscription:
+			subscriptions = append(subscriptions, operation{name, rpc})
+		default:
 			continue
 		}
-		queryName := opt.GetQuery()
-		mutationName := opt.GetMutation()
-		if queryName != "" && mutationName != "" {
-			panic("conflict graphql operator for method: " + rpc.GoName)
+		if isHidden(rpc.Input, GQLTypeInput) {
+			panic("graphql method input must not be skipped or output only: " + rpc.GoName)
+		}
+		fields, err := responseFields(rpc.Output, methodOption(rpc).GetResponseField())
+		if err != nil {
+			panic(err.Error() + ": " + rpc.GoName)
 		}
-		if queryName != "" {
-			queries[queryName] = rpc
+		if len(fields) == 0 && isHidden(rpc.Output, GQLTypeObject) {
+			panic("graphql method output must not be skipped or input only: " + rpc.GoName)
 		}
-		if mutationName != "" {
-			mutations[mutationName] = rpc
+		if len(fields) > 0 && isSkipped(fields[len(fields)-1], GQLTypeObject) {
+			panic("graphql method response field must not be skipped: " + rpc.GoName)
+		}
+		if _, ok := seen[rpc.Input]; !ok {
+			seen[rpc.Input] = struct{}{}
+			inputs = append(inputs, rpc.Input)
 		}
-		inputs[rpc.Input] = struct{}{}
 	}
-	for m := range inputs {
-		v.VisitMessage(root, m, GQLTypeInput)
+	for _, m := range inputs {
+		v.VisitMessage(v.root, m, GQLTypeInput)
 	}
-	if len(queries) > 0 {
-		v.P("func Register", p.GoName, "Queries(sc ", p.GoName, "Client) error {")
-		v.Enter()
-		for name, q := range queries {
-			v.visitMethod(symbol, q, name, GQLTypeQuery)
-		}
-		v.P("return nil")
-		v.Exit()
-		v.P("}")
+	v.appendOperations(symbol, p, queries, GQLTypeQuery)
+	v.appendOperations(symbol, p, mutations, GQLTypeMutation)
+	v.appendOperations(symbol, p, subscriptions, GQLTypeSubscription)
+	v.visitRegisterOperations(symbol, p, queries, GQLTypeQuery)
+	v.visitRegisterOperations(symbol, p, mutations, GQLTypeMutation)
+	v.visitRegisterOperations(symbol, p, subscriptions, GQLTypeSubscription)
+}
+
+// visitRegisterOperations declares the function registering the operations
+// of a service, along with the types they use, to a registry, and its
+// wrapper using the default registry
+func (v *visitor) visitRegisterOperations(symbol *Symbol, p *protogen.Service, operations []operation, typ GQLType) {
+	if len(operations) == 0 {
+		return
+	}
+	edgeRegistry := goIdent(edgeImport, "Registry")
+	name := "Register" + p.GoName + string(typ) + "s"
+	if typ == GQLTypeQuery {
+		name = "Register" + p.GoName + "Queries"
 	}
 	v.P("")
-	if len(mutations) > 0 {
-		v.P("func Register", p.GoName, "Mutations(sc ", p.GoName, "Client) error {")
-		v.Enter()
-		for name, m := range mutations {
-			v.visitMethod(symbol, m, name, GQLTypeMutation)
+	v.P("func ", name, "(sc ", p.GoName, "Client) error {")
+	v.Enter()
+	v.P("return ", name, "To(", v.defaultRegistry(), ", sc)")
+	v.Exit()
+	v.P("}")
+	v.P("")
+	v.P("func ", name, "To(reg *", edgeRegistry, ", sc ", p.GoName, "Client) error {")
+	v.Enter()
+	for _, f := range v.tbl.typeFiles(v.File) {
+		v.P(typesFunc(f), "(reg)")
+	}
+	for _, op := range operations {
+		v.visitMethod(symbol, op.Method, op.Name, typ)
+	}
+	v.P("return nil")
+	v.Exit()
+	v.P("}")
+}
+
+func (v *visitor) appendOperations(parent *Symbol, p *protogen.Service, operations []operation, typ GQLType) {
+	for _, op := range operations {
+		rpc := op.Method
+		ident := GQLIdent{
+			protogen.GoIdent{
+				GoName:       p.GoName + "_" + rpc.GoName,
+				GoImportPath: v.GoImportPath,
+			},
+			typ,
+			v.GeneratedFile,
 		}
-		v.P("return nil")
-		v.Exit()
-		v.P("}")
+		sym := NewSymbol(parent, ident)
+		sym.File = v.File
+		sym.Method = rpc
+		sym.Name = op.Name
+		v.tbl.Append(sym)
 	}
 }
 
 func (v *visitor) visitMethod(symbol *Symbol, p *protogen.Method, optionName string, methodType GQLType) {
-	jsonMarshal := goIdent("encoding/json", "Marshal")
-	jsonUnmarshal := goIdent("google.golang.org/protobuf/encoding/protojson", "Unmarshal")
 	gqlField := goIdent(graphqlImport, "Field")
 	switch methodType {
 	case GQLTypeQuery:
-		edgeQuery := goIdent(edgeImport, "RegisterQuery")
-		v.P(edgeQuery, "(", quot(optionName), ", &", gqlField, "{")
+		v.P("reg.RegisterQuery(", quot(optionName), ", &", gqlField, "{")
 	case GQLTypeMutation:
-		edgeMutation := goIdent(edgeImport, "RegisterMutation")
-		v.P(edgeMutation, "(", quot(optionName), ", &", gqlField, "{")
+		v.P("reg.RegisterMutation(", quot(optionName), ", &", gqlField, "{")
+	case GQLTypeSubscription:
+		v.P("reg.RegisterSubscription(", quot(optionName), ", &", gqlField, "{")
 	default:
-		panic("graphql method must be `query` or `mutation`, got: " + optionName)
+		panic("graphql method must be `query`, `mutation` or `subscription`, got: " + optionName)
 	}
 	gqlFieldConfigArgument := goIdent(graphqlImport, "FieldConfigArgument")
 	gqlArgumentConfig := goIdent(graphqlImport, "ArgumentConfig")
-	gqlResolveParams := goIdent(graphqlImport, "ResolveParams")
 	v.Enter()
 	v.P("Name: ", quot(optionName), ",")
+	v.visitTypeDescription(p.Desc)
+	if reason := methodDeprecation(p); reason != "" {
+		v.P("DeprecationReason: ", quot(reason), ",")
+	}
 	v.P("Args: ", gqlFieldConfigArgument, "{")
 	v.Enter()
-	v.P(quot("input"), ": &", gqlArgumentConfig, "{")
-	v.Enter()
-	input := v.getType(protoreflect.MessageKind, p.Input.GoIdent, p.Input.Desc, GQLTypeInput)
-	v.P("Type: ", input, ",")
+	if v.flattensArgs(p) {
+		v.visitArgs(p.Input)
+	} else {
+		v.P(quot("input"), ": &", gqlArgumentConfig, "{")
+		v.Enter()
+		input := v.getType(protoreflect.MessageKind, p.Input.GoIdent, p.Input.Desc, GQLTypeInput)
+		v.P("Type: ", input, ",")
+		v.Exit()
+		v.P("},")
+	}
 	v.Exit()
 	v.P("},")
+	v.visitResponseType(p)
+	if methodType == GQLTypeSubscription {
+		v.visitStreamResolver(p)
+	} else {
+		v.visitUnaryResolver(p)
+	}
 	v.Exit()
-	v.P("},")
-	output := v.getType(protoreflect.MessageKind, p.Output.GoIdent, p.Output.Desc, GQLTypeObject)
-	v.P("Type: ", output, ",")
-	v.P("Resolve: func(p ", gqlResolveParams, ") (interface{}, error) {")
-	v.Enter()
+	v.P("})")
+}
+
+// visitArgs declares the fields of the rpc input as arguments, typed as the
+// fields of its input type
+func (v *visitor) visitArgs(m *protogen.Message) {
+	gqlArgumentConfig := goIdent(graphqlImport, "ArgumentConfig")
+	for _, f := range m.Fields {
+		if isOneofMember(f) || isSkipped(f, GQLTypeInput) {
+			continue
+		}
+		v.P(quot(fieldName(f)), ": &", gqlArgumentConfig, "{")
+		v.Enter()
+		if f.Desc.IsMap() {
+			v.visitFieldType(f, goIdent(edgeImport, "Scalar_JSON"), false, GQLTypeInput)
+		} else {
+			v.visitFieldType(f, v.getEdgeType(f.Desc.Kind(), f.GoIdent, f, GQLTypeInput), f.Desc.IsList(), GQLTypeInput)
+		}
+		v.visitFieldDoc(f, GQLTypeInput)
+		v.Exit()
+		v.P("},")
+	}
+	for _, o := range oneofs(m) {
+		v.P(quot(string(o.Desc.Name())), ": &", gqlArgumentConfig, "{")
+		v.Enter()
+		v.P("Type: ", v.typeIdent(o.GoIdent, m.Desc, GQLTypeInput), ",")
+		v.visitTypeDescription(o.Desc)
+		v.Exit()
+		v.P("},")
+	}
+}
+
+// visitRequest decodes the rpc input from the `input` argument, or from all
+// the arguments when they are flattened
+func (v *visitor) visitRequest(p *protogen.Method) {
+	jsonUnmarshal := goIdent("google.golang.org/protobuf/encoding/protojson", "Unmarshal")
+	input := v.getType(protoreflect.MessageKind, p.Input.GoIdent, p.Input.Desc, GQLTypeInput)
 	v.P("var req ", p.Input.GoIdent)
-	v.P("rawJson, err := ", jsonMarshal, "(p.Args[", quot("input"), "])")
+	if v.flattensArgs(p) {
+		v.P("rawJson, err := reg.MarshalInput(", input, ", p.Args)")
+	} else {
+		v.P("rawJson, err := reg.MarshalInput(", input, ", p.Args[", quot("input"), "])")
+	}
 	v.P("if err != nil {")
 	v.Enter()
 	v.P("return nil, err")
@@ -568,13 +969,124 @@ func (v *visitor) visitMethod(symbol *Symbol, p *protogen.Method, optionName str
 	v.P("return nil, err")
 	v.Exit()
 	v.P("}")
+}
+
+func (v *visitor) visitUnaryResolver(p *protogen.Method) {
+	gqlResolveParams := goIdent(graphqlImport, "ResolveParams")
+	v.P("Resolve: func(p ", gqlResolveParams, ") (interface{}, error) {")
+	v.Enter()
+	v.visitRequest(p)
 	v.P("var res *", p.Output.GoIdent)
-	v.P("res, err = sc.", p.GoName, "(p.Context, &req)")
-	v.P("return res, err")
+	v.P("res, err = sc.", p.GoName, "(p.Context, &req, ", goIdent(edgeImport, "CallOptions"), "(p.Context)...)")
+	v.P("if err != nil {")
+	v.Enter()
+	v.P("return nil, reg.MapError(p.Context, err)")
+	v.Exit()
+	v.P("}")
+	v.P("return res", responseGetters(p), ", nil")
+	v.Exit()
+	v.P("},")
+}
+
+func (v *visitor) visitStreamResolver(p *protogen.Method) {
+	gqlResolveParams := goIdent(graphqlImport, "ResolveParams")
+	ioEOF := goIdent("io", "EOF")
+	v.P("Subscribe: func(p ", gqlResolveParams, ") (interface{}, error) {")
+	v.Enter()
+	{
+		v.visitRequest(p)
+		// no CallOptions: the HTTP headers of a subscription are written
+		// before its stream returns any response metadata
+		v.P("stream, err := sc.", p.GoName, "(p.Context, &req)")
+		v.P("if err != nil {")
+		v.Enter()
+		v.P("return nil, reg.MapError(p.Context, err)")
+		v.Exit()
+		v.P("}")
+		v.P("ch := make(chan interface{})")
+		v.P("go func() {")
+		v.Enter()
+		{
+			v.P("defer close(ch)")
+			v.P("for {")
+			v.Enter()
+			{
+				v.P("var msg interface{}")
+				v.P("res, err := stream.Recv()")
+				v.P("if err == ", ioEOF, " {")
+				v.Enter()
+				v.P("return")
+				v.Exit()
+				v.P("} else if err != nil {")
+				v.Enter()
+				v.P("msg = reg.MapError(p.Context, err)")
+				v.Exit()
+				v.P("} else {")
+				v.Enter()
+				v.P("msg = res", responseGetters(p))
+				v.Exit()
+				v.P("}")
+				v.P("select {")
+				v.P("case ch <- msg:")
+				v.P("case <-p.Context.Done():")
+				v.Enter()
+				v.P("return")
+				v.Exit()
+				v.P("}")
+				v.P("if err != nil {")
+				v.Enter()
+				v.P("return")
+				v.Exit()
+				v.P("}")
+			}
+			v.Exit()
+			v.P("}")
+		}
+		v.Exit()
+		v.P("}()")
+		v.P("return ch, nil")
+	}
 	v.Exit()
 	v.P("},")
+	v.P("Resolve: func(p ", gqlResolveParams, ") (interface{}, error) {")
+	v.Enter()
+	{
+		v.P("if err, ok := p.Source.(error); ok {")
+		v.Enter()
+		v.P("return nil, err")
+		v.Exit()
+		v.P("}")
+		v.P("return p.Source, nil")
+	}
 	v.Exit()
-	v.P("})")
+	v.P("},")
+}
+
+// visitResponseType prints the type of the operation, the object type of the
+// rpc output or the type of its response field
+func (v *visitor) visitResponseType(p *protogen.Method) {
+	fields, _ := responseFields(p.Output, methodOption(p).GetResponseField())
+	if len(fields) == 0 {
+		output := v.getType(protoreflect.MessageKind, p.Output.GoIdent, p.Output.Desc, GQLTypeObject)
+		v.P("Type: ", output, ",")
+		return
+	}
+	f := fields[len(fields)-1]
+	v.visitFieldType(f, v.getEdgeType(f.Desc.Kind(), f.GoIdent, f, GQLTypeObject), f.Desc.IsList(), GQLTypeObject)
+}
+
+// responseGetters returns the getter calls of the response field path, which
+// return the zero value of the field when a message along the path is unset
+func responseGetters(p *protogen.Method) string {
+	fields, _ := responseFields(p.Output, methodOption(p).GetResponseField())
+	getters := ""
+	for _, f := range fields {
+		getters += ".Get" + f.GoName + "()"
+	}
+	if len(fields) > 0 && fields[len(fields)-1].Enum != nil {
+		getters += ".String()"
+	}
+	return getters
 }
 
 func (v *visitor) getType(kind protoreflect.Kind, ident protogen.GoIdent, desc protoreflect.Descriptor, typ GQLType) protogen.GoIdent {
@@ -667,15 +1179,7 @@ func (v *visitor) getType(kind protoreflect.Kind, ident protogen.GoIdent, desc p
 			GoImportPath: graphqlImport,
 		}
 	case protoreflect.EnumKind:
-		ident := GQLIdent{
-			ident,
-			GQLTypeEnum,
-			v.GeneratedFile,
-		}
-		return protogen.GoIdent{
-			GoName:       ident.String(),
-			GoImportPath: ident.GoImportPath,
-		}
+		return v.typeIdent(ident, desc, GQLTypeEnum)
 	case protoreflect.MessageKind:
 		if ident, ok := wellKnownImports[string(desc.FullName())]; ok {
 			return protogen.GoIdent{
@@ -683,10 +1187,7 @@ func (v *visitor) getType(kind protoreflect.Kind, ident protogen.GoIdent, desc p
 				GoImportPath: edgeImport,
 			}
 		}
-		return protogen.GoIdent{
-			GoName:       string(typ) + "_" + string(ident.GoName),
-			GoImportPath: ident.GoImportPath,
-		}
+		return v.typeIdent(ident, desc, typ)
 	}
 	panic("failed to get type for: " + ident.String())
 }
@@ -702,6 +1203,44 @@ func (v *visitor) getEdgeType(kind protoreflect.Kind, ident protogen.GoIdent, p
 	}
 }
 
+// typeIdent returns the go variable of the graphql type generated for a
+// message or an enum: the variable of the file owning the type when it is
+// generated by the run, or else the copy declared by the visited package
+func (v *visitor) typeIdent(ident protogen.GoIdent, d protoreflect.Descriptor, typ GQLType) protogen.GoIdent {
+	if _, ok := v.tbl.owner(d); ok {
+		return goIdent(ident.GoImportPath, string(typ)+"_"+ident.GoName)
+	}
+	local := GQLIdent{ident, typ, v.GeneratedFile}
+	return goIdent(v.GoImportPath, local.String())
+}
+
+// declares reports whether the visited file declares the types of a message
+// or an enum, which are otherwise declared by the file owning them
+func (v *visitor) declares(d protoreflect.Descriptor) bool {
+	owner, ok := v.tbl.owner(d)
+	return !ok || owner == v.File
+}
+
+func allMessages(messages []*protogen.Message) []*protogen.Message {
+	res := make([]*protogen.Message, 0)
+	for _, m := range messages {
+		res = append(res, m)
+		res = append(res, allMessages(m.Messages)...)
+	}
+	return res
+}
+
+func isWrappedMember(f *protogen.Field) bool {
+	return graphql.IsWrappedMember(f.Desc)
+}
+
+// isWellKnown reports whether the message is represented by a type of the
+// graphql-grpc-edge package
+func isWellKnown(d protoreflect.MessageDescriptor) bool {
+	_, ok := graphql.WellKnownOutput(d.FullName())
+	return ok
+}
+
 func quot(str string) string {
 	return strconv.Quote(str)
 }
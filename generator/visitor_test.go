@@ -1,10 +1,13 @@
 package generator
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -21,11 +24,13 @@ var (
 	fds   *descriptorpb.FileDescriptorSet = &descriptorpb.FileDescriptorSet{}
 	files *protoregistry.Files            = &protoregistry.Files{}
 	p     *protogen.Plugin
+
+	update = flag.Bool("update", false, "update the golden files in testdata")
 )
 
 func TestMain(m *testing.M) {
 	os.RemoveAll("test.pb.descriptor")
-	cmd := exec.Command("protoc", "-o", "test.pb.descriptor", "--include_imports", "-I", "../../", "-I", ".", "test.proto")
+	cmd := exec.Command("protoc", "-o", "test.pb.descriptor", "--include_imports", "--include_source_info", "-I", "../../", "-I", ".", "test.proto")
 	err := cmd.Start()
 	if err != nil {
 		panic(fmt.Errorf("failed to generate protobuf descriptor: %s, %q", err.Error(), strings.Join(cmd.Args, " ")))
@@ -107,8 +112,9 @@ var Enum_generator_UserStatus *graphql.Enum = graphql.NewEnum(
 		t.Run(c.name, func(t *testing.T) {
 			importPath := p.FilesByPath["test.proto"].GoImportPath
 			g := p.NewGeneratedFile("test.pb.graphql.go", importPath)
-			v := NewVisitor(f, g, importPath.String())
-			v.VisitEnum(root, c.enum)
+			tbl := NewSymbolTable(f)
+			v := NewVisitor(tbl, f, g, importPath.String(), DefaultOptions())
+			v.VisitEnum(tbl.Root(), c.enum)
 			res, err := v.Content()
 			if err != nil {
 				t.Fatalf("failed to parse golang enum: %s", err.Error())
@@ -120,638 +126,628 @@ var Enum_generator_UserStatus *graphql.Enum = graphql.NewEnum(
 	}
 }
 
-func TestVisitMessage(t *testing.T) {
+func TestVisitSubscription(t *testing.T) {
 	f, ok := p.FilesByPath["test.proto"]
 	if !ok {
 		panic("failed to read FileDescriptor")
 	}
-	messageCode := `var Object_generator_Test *graphql.Object = graphql.NewObject(graphql.ObjectConfig{
-	Name: "Object_generator_Test",
-	IsTypeOf: func(p graphql.IsTypeOfParams) bool {
-		return true
-	},
-	Fields: graphql.Fields{
-		"name": &graphql.Field{
-			Name: "name",
-			Type: graphql.String,
-			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-				var res interface{}
-				if pdata, ok := p.Source.(*Test); ok {
-					res = pdata.Name
-				} else if data, ok := p.Source.(Test); ok {
-					res = data.Name
-				}
-				return res, nil
-			},
-		},
-	},
-})
-`
-	// TODO(lim): More field types
-	// {{- range $union, $unionFields := $obj.Unions }}
-	// "{{ $union }}": &graphql.Field{
-	// 	Name: "{{ $union }}",
-	// 	Type: GraphQL_{{ GetGraphQLTypeName $fqmn }}_{{ $union }}Union,
-	// },
-	// {{- end }}
-	// {{- range $mapField := $obj.Maps }}
-	// "{{ $mapField.GetName }}": &graphql.Field{
-	// 	Name: "{{ $mapField.GetName }}",
-	// 	Type: graphql.NewList(GraphQL_{{ GetGraphQLTypeName $mapField.GetTypeName }}Map),
-	// 	Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-	// 		var tmp interface{}
-	// 		if pdata, ok := p.Source.(*{{ GetLanguageType $fqmn }}); ok {
-	// 			tmp = pdata.{{ GetProtobufFieldName $mapField.GetName }}
-	// 		} else if data, ok := p.Source.({{ GetLanguageType $fqmn }}); ok {
-	// 			tmp = data.{{ GetProtobufFieldName $mapField.GetName }}
-	// 		}
-	// 		kv, ok := tmp.(map[{{ (index $obj.MapTypes $mapField.GetTypeName).KeyType }}]{{ (index $obj.MapTypes $mapField.GetTypeName).ValueType }})
-	// 		if !ok {
-	// 			return nil, graphqlpb.ErrBadValue
-	// 		}
-	// 		res := make([]interface{}, 0)
-	// 		for key, value := range kv {
-	// 		    rec := map[string]interface{}{
-	// 			"key": key,
-	// 			"value": value,
-	// 		    }
-	// 		    res = append(res, rec)
-	// 		}
-	// 		return res, nil
-	// 	},
-	// },
+	g := p.NewGeneratedFile("test.graphql.pb.go", f.GoImportPath)
+	tbl := NewSymbolTable(f)
+	v := NewVisitor(tbl, f, g, f.GoImportPath.String(), DefaultOptions())
+	for _, m := range f.Messages {
+		v.VisitMessage(tbl.Root(), m, GQLTypeObject)
+		v.VisitMessage(tbl.Root(), m, GQLTypeInput)
+	}
+	v.VisitService(tbl.Root(), f.Services[1])
+	b, err := v.Content()
+	if err != nil {
+		t.Fatalf("failed to generate file: %s", err.Error())
+	}
+	content := strings.Join(strings.Fields(string(b)), " ")
+	want := []string{
+		"func RegisterHelloTestServiceSubscriptions(sc HelloTestServiceClient) error { return RegisterHelloTestServiceSubscriptionsTo(",
+		`, sc HelloTestServiceClient) error { RegisterTypes_test_proto(reg) reg.RegisterSubscription("helloStream", &`,
+		"Subscribe: func(p ",
+		"stream, err := sc.HelloSubscription(p.Context, &req)",
+		"res, err := stream.Recv() if err == io.EOF { return } else if err != nil { msg = reg.MapError(p.Context, err) } else { msg = res }",
+		"select { case ch <- msg: case <-p.Context.Done(): return }",
+		"if err, ok := p.Source.(error); ok { return nil, err } return p.Source, nil",
+	}
+	for _, w := range want {
+		if !strings.Contains(content, w) {
+			t.Errorf("expected %q in:\n%s", w, b)
+		}
+	}
+	if strings.Contains(content, `RegisterQuery("helloStream"`) || strings.Contains(content, `RegisterMutation("helloStream"`) {
+		t.Error("expected helloStream to be registered as a subscription only")
+	}
+}
+
+func TestVisitFieldOptions(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	var msg *protogen.Message
+	for _, m := range f.Messages {
+		if m.GoIdent.GoName == "TestFieldOptions" {
+			msg = m
+		}
+	}
 	cases := []struct {
-		name       string
-		message    *protogen.Message
-		wantString string
+		name    string
+		typ     GQLType
+		want    []string
+		notWant []string
 	}{
 		{
-			name:       "Test",
-			message:    f.Messages[0],
-			wantString: messageCode,
+			name: "object",
+			typ:  GQLTypeObject,
+			want: []string{
+				"\"id\": &graphql.Field{ Type: graphql.NewNonNull(graphql.String), Description: \"identifier\",",
+				"\"computed\": &graphql.Field{",
+				"\"newName\": &graphql.Field{ Type: graphql.String, DeprecationReason: \"use id\",",
+				"\"tags\": &graphql.Field{ Type: graphql.NewNonNull(graphql.NewList(graphql.String)),",
+				"\"userStatus\": &graphql.Field{",
+			},
+			notWant: []string{"\"secret\"", "\"labels\"", "\"oldName\""},
 		},
 		{
-			name:       "TestRepeated",
-			message:    f.Messages[1],
-			wantString: messageCode,
+			name: "input",
+			typ:  GQLTypeInput,
+			want: []string{
+				"\"id\": &graphql.InputObjectFieldConfig{ Type: graphql.NewNonNull(graphql.String), Description: \"identifier\",",
+				"\"secret\": &graphql.InputObjectFieldConfig{",
+				"\"newName\": &graphql.InputObjectFieldConfig{ Type: graphql.String,",
+			},
+			notWant: []string{"\"computed\"", "\"labels\"", "DeprecationReason"},
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			g := p.NewGeneratedFile("test.graphql.pb.go", p.FilesByPath["test.proto"].GoImportPath)
-			v := NewVisitor(f, g, p.Files[0].GoImportPath.String())
-			v.VisitMessage(root, c.message, GQLTypeObject)
-			res, err := v.Content()
+			tbl := NewSymbolTable(f)
+			g := p.NewGeneratedFile("test.graphql.pb.go", f.GoImportPath)
+			v := NewVisitor(tbl, f, g, f.GoImportPath.String(), DefaultOptions())
+			v.VisitMessage(tbl.Root(), msg, c.typ)
+			b, err := v.Content()
 			if err != nil {
 				t.Fatalf("failed to generate file: %s", err.Error())
 			}
-			fmt.Println(string(res))
+			// compare the generated code regardless of its indentation
+			res := strings.Join(strings.Fields(string(b)), " ")
+			for _, want := range c.want {
+				if !strings.Contains(res, want) {
+					t.Errorf("expected %q in:\n%s", want, res)
+				}
+			}
+			for _, notWant := range c.notWant {
+				if strings.Contains(res, notWant) {
+					t.Errorf("unexpected %q in:\n%s", notWant, res)
+				}
+			}
 		})
 	}
 }
 
-func TestVisitService(t *testing.T) {
+func TestVisitTypeOptions(t *testing.T) {
 	f, ok := p.FilesByPath["test.proto"]
 	if !ok {
 		panic("failed to read FileDescriptor")
 	}
-	wantSvcString := `package descriptorpb
+	tbl := NewSymbolTable(f)
+	g := p.NewGeneratedFile("test.graphql.pb.go", f.GoImportPath)
+	v := NewVisitor(tbl, f, g, f.GoImportPath.String(), DefaultOptions())
+	for _, e := range f.Enums {
+		v.VisitEnum(tbl.Root(), e)
+	}
+	for _, m := range f.Messages {
+		v.VisitMessage(tbl.Root(), m, GQLTypeObject)
+	}
+	b, err := v.Content()
+	if err != nil {
+		t.Fatalf("failed to generate file: %s", err.Error())
+	}
+	// compare the generated code regardless of its indentation
+	res := strings.Join(strings.Fields(string(b)), " ")
+	want := []string{
+		"Name: \"Level\", Description: \"severity level\",",
+		"\"low\": &graphql.EnumValueConfig{ Value: TestLevel_name[0], Description: \"lowest level\", },",
+		"\"LEVEL_HIGH\": &graphql.EnumValueConfig{ Value: TestLevel_name[1], Description: \"highest level\", DeprecationReason: \"use low\", },",
+		"Name: \"Object_TestMessageOptions\", Description: \"message options\",",
+		"\"outputOnly\": &graphql.Field{ Type: Object_TestOutputOnly,",
+	}
+	for _, w := range want {
+		if !strings.Contains(res, w) {
+			t.Errorf("expected %q in:\n%s", w, res)
+		}
+	}
+	notWant := []string{"Enum_TestHiddenEnum", "Object_TestSkipped", "Object_TestInputOnly", "\"skipped\"", "\"hiddenEnum\""}
+	for _, w := range notWant {
+		if strings.Contains(res, w) {
+			t.Errorf("unexpected %q in:\n%s", w, res)
+		}
+	}
+}
 
-import (
-	json "encoding/json"
-	graphql "github.com/ncrypthic/graphql-grpc-edge/graphql"
-	durationpb "google.golang.org/protobuf/types/known/durationpb"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
-	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
-	wrappers "google.golang.org/protobuf/types/known/wrappers"
-)
+func TestVisitDescriptions(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	var msg *protogen.Message
+	for _, m := range f.Messages {
+		if m.GoIdent.GoName == "TestDescribed" {
+			msg = m
+		}
+	}
+	want := []string{
+		"Name: \"Object_TestDescribed_Kind\", Description: \"kind of the item\",",
+		"Name: \"Object_TestDescribed\", Description: \"TestDescribed is documented by its comments,\\nwhich span two lines.\",",
+		"\"name\": &graphql.Field{ Type: graphql.String, Description: \"name of the item\",",
+		"\"title\": &graphql.Field{ Type: graphql.String, Description: \"explicit title\",",
+		"\"kind\": &graphql.Field{ Type: Object_TestDescribed_Kind, Description: \"kind of the item\",",
+	}
+	for _, enabled := range []bool{true, false} {
+		tbl := NewSymbolTable(f)
+		opts := DefaultOptions()
+		opts.Descriptions = enabled
+		g := p.NewGeneratedFile("test.graphql.pb.go", f.GoImportPath)
+		v := NewVisitor(tbl, f, g, f.GoImportPath.String(), opts)
+		v.VisitMessage(tbl.Root(), msg, GQLTypeObject)
+		b, err := v.Content()
+		if err != nil {
+			t.Fatalf("failed to generate file: %s", err.Error())
+		}
+		res := strings.Join(strings.Fields(string(b)), " ")
+		if !enabled {
+			if strings.Contains(res, "Description") {
+				t.Errorf("unexpected descriptions in:\n%s", res)
+			}
+			continue
+		}
+		for _, w := range want {
+			if !strings.Contains(res, w) {
+				t.Errorf("expected %q in:\n%s", w, res)
+			}
+		}
+	}
+}
 
-var Input_TestScalar *graphql.InputObject = graphql.NewInputObject(
-	graphql.InputObjectConfig{
-		Name: "Input_TestScalar",
-		IsTypeOf: func(g graphql.IsTypeOfParams) bool {
-			return true
-		},
-		Fields: graphql.InputObjectConfigFieldMap{
-			"field1": &graphql.Field{
-				Type: graphql.String,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field1
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field1
-					}
-					return res, nil
-				},
-			},
-			"field2": &graphql.Field{
-				Type: graphql.Bool,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field2
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field2
-					}
-					return res, nil
-				},
-			},
-			"field3": &graphql.Field{
-				Type: graphql.Int,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field3
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field3
-					}
-					return res, nil
-				},
-			},
-			"field4": &graphql.Field{
-				Type: graphql.Int,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field4
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field4
-					}
-					return res, nil
-				},
-			},
-			"field5": &graphql.Field{
-				Type: graphql.Float,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field5
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field5
-					}
-					return res, nil
-				},
-			},
-			"field6": &graphql.Field{
-				Type: graphql.Float,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field6
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field6
-					}
-					return res, nil
-				},
-			},
-			"field7": &graphql.Field{
-				Type: graphql.Int,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field7
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field7
-					}
-					return res, nil
-				},
-			},
-			"field8": &graphql.Field{
-				Type: graphql.Int,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field8
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field8
-					}
-					return res, nil
-				},
-			},
-			"field9": &graphql.Field{
-				Type: graphql.Int,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field9
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field9
-					}
-					return res, nil
-				},
-			},
-			"field10": &graphql.Field{
-				Type: graphql.Int,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field10
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field10
-					}
-					return res, nil
-				},
-			},
-			"field11": &graphql.Field{
-				Type: graphql.Int,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field11
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field11
-					}
-					return res, nil
-				},
-			},
-			"field12": &graphql.Field{
-				Type: graphql.Int,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field12
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field12
-					}
-					return res, nil
-				},
-			},
-			"field13": &graphql.Field{
-				Type: graphql.Int,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field13
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field13
-					}
-					return res, nil
-				},
-			},
-			"field14": &graphql.Field{
-				Type: graphql.Int,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestScalar); ok {
-						res = pdata.Field14
-					} else if data, ok := p.Source.(TestScalar); ok {
-						res = data.Field14
-					}
-					return res, nil
-				},
+func TestVisitDeprecations(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	tbl := NewSymbolTable(f)
+	g := p.NewGeneratedFile("test.graphql.pb.go", f.GoImportPath)
+	v := NewVisitor(tbl, f, g, f.GoImportPath.String(), DefaultOptions())
+	for _, e := range f.Enums {
+		v.VisitEnum(tbl.Root(), e)
+	}
+	for _, svc := range f.Services {
+		if svc.GoName == "DescribedService" {
+			v.VisitService(tbl.Root(), svc)
+		}
+	}
+	b, err := v.Content()
+	if err != nil {
+		t.Fatalf("failed to generate file: %s", err.Error())
+	}
+	res := strings.Join(strings.Fields(string(b)), " ")
+	want := []string{
+		"reg.RegisterQuery(\"legacy\", &graphql.Field{ Name: \"legacy\", DeprecationReason: \"No longer supported\",",
+		"\"DEPRECATED_OLD\": &graphql.EnumValueConfig{ Value: TestDeprecatedEnum_name[1], DeprecationReason: \"No longer supported\", },",
+	}
+	for _, w := range want {
+		if !strings.Contains(res, w) {
+			t.Errorf("expected %q in:\n%s", w, res)
+		}
+	}
+	if strings.Contains(res, "reg.RegisterQuery(\"describe\", &graphql.Field{ Name: \"describe\", Description: \"describe returns the item as is\", DeprecationReason") {
+		t.Errorf("unexpected deprecation of describe in:\n%s", res)
+	}
+}
+
+func TestVisitNullability(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	var msg *protogen.Message
+	for _, m := range f.Messages {
+		if m.GoIdent.GoName == "TestNullability" {
+			msg = m
+		}
+	}
+	field := func(name, typ string) string {
+		return fmt.Sprintf("%q: &graphql.Field{ Type: %s,", name, typ)
+	}
+	inputField := func(name, typ string) string {
+		return fmt.Sprintf("%q: &graphql.InputObjectFieldConfig{ Type: %s,", name, typ)
+	}
+	cases := []struct {
+		name        string
+		nullability string
+		typ         GQLType
+		want        []string
+	}{
+		{
+			name:        "nullable object",
+			nullability: NullabilityNullable,
+			typ:         GQLTypeObject,
+			want: []string{
+				field("name", "graphql.String"),
+				field("nickname", "graphql.String"),
+				field("scores", "graphql.NewList(graphql.Int)"),
+				field("detail", "Object_Test_TestDetail"),
+				field("status", "Enum_UserStatus"),
+				field("labels", "graphql1.Scalar_JSON"),
+				field("id", "graphql.NewNonNull(graphql.String)"),
 			},
 		},
-	},
-)
-var Input_Test_TestDetail *graphql.InputObject = graphql.NewInputObject(
-	graphql.InputObjectConfig{
-		Name: "Input_Test_TestDetail",
-		IsTypeOf: func(g graphql.IsTypeOfParams) bool {
-			return true
-		},
-		Fields: graphql.InputObjectConfigFieldMap{
-			"name": &graphql.Field{
-				Type: graphql.String,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test_TestDetail); ok {
-						res = pdata.Name
-					} else if data, ok := p.Source.(Test_TestDetail); ok {
-						res = data.Name
-					}
-					return res, nil
-				},
-			},
-			"photo": &graphql.Field{
-				Type: graphql.graphql.Scalar_bytes,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test_TestDetail); ok {
-						res = pdata.Photo
-					} else if data, ok := p.Source.(Test_TestDetail); ok {
-						res = data.Photo
-					}
-					return res, nil
-				},
+		{
+			name:        "proto object",
+			nullability: NullabilityProto,
+			typ:         GQLTypeObject,
+			want: []string{
+				field("name", "graphql.NewNonNull(graphql.String)"),
+				field("nickname", "graphql.String"),
+				field("scores", "graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int)))"),
+				field("detail", "Object_Test_TestDetail"),
+				field("status", "graphql.NewNonNull(Enum_UserStatus)"),
+				field("labels", "graphql1.Scalar_JSON"),
+				field("id", "graphql.NewNonNull(graphql.String)"),
 			},
 		},
-	},
-)
-var Input_Test_AttributesEntry *graphql.InputObject = graphql.NewInputObject(
-	graphql.InputObjectConfig{
-		Name: "Input_Test_AttributesEntry",
-		IsTypeOf: func(g graphql.IsTypeOfParams) bool {
-			return true
-		},
-		Fields: graphql.InputObjectConfigFieldMap{
-			"key": &graphql.Field{
-				Type: graphql.String,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test_AttributesEntry); ok {
-						res = pdata.Key
-					} else if data, ok := p.Source.(Test_AttributesEntry); ok {
-						res = data.Key
-					}
-					return res, nil
-				},
-			},
-			"value": &graphql.Field{
-				Type: graphql.String,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test_AttributesEntry); ok {
-						res = pdata.Value
-					} else if data, ok := p.Source.(Test_AttributesEntry); ok {
-						res = data.Value
-					}
-					return res, nil
-				},
+		{
+			name:        "proto input",
+			nullability: NullabilityProto,
+			typ:         GQLTypeInput,
+			want: []string{
+				inputField("name", "graphql.String"),
+				inputField("nickname", "graphql.String"),
+				inputField("scores", "graphql.NewList(graphql.NewNonNull(graphql.Int))"),
+				inputField("status", "Enum_UserStatus"),
+				inputField("id", "graphql.NewNonNull(graphql.String)"),
 			},
 		},
-	},
-)
-var Input_Test *graphql.InputObject = graphql.NewInputObject(
-	graphql.InputObjectConfig{
-		Name: "Input_Test",
-		IsTypeOf: func(g graphql.IsTypeOfParams) bool {
-			return true
-		},
-		Fields: graphql.InputObjectConfigFieldMap{
-			"name": &graphql.Field{
-				Type: graphql.String,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test); ok {
-						res = pdata.Name
-					} else if data, ok := p.Source.(Test); ok {
-						res = data.Name
-					}
-					return res, nil
-				},
-			},
-			"maybeString": &graphql.Field{
-				Type: graphql.Input_wrappers_StringValue,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test); ok {
-						res = pdata.MaybeString
-					} else if data, ok := p.Source.(Test); ok {
-						res = data.MaybeString
-					}
-					return res, nil
-				},
-			},
-			"detail": &graphql.Field{
-				Type: Input_Test_TestDetail,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test); ok {
-						res = pdata.Detail
-					} else if data, ok := p.Source.(Test); ok {
-						res = data.Detail
-					}
-					return res, nil
-				},
-			},
-			"country": &graphql.Field{
-				Type: Enum_Test_Country,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test); ok {
-						res = pdata.Country
-					} else if data, ok := p.Source.(Test); ok {
-						res = data.Country
-					}
-					return res, nil
-				},
-			},
-			"state": &graphql.Field{
-				Type: Enum_Test_State,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test); ok {
-						res = pdata.State
-					} else if data, ok := p.Source.(Test); ok {
-						res = data.State
-					}
-					return res, nil
-				},
-			},
-			"createdAt": &graphql.Field{
-				Type: graphql.Scalar_timestamppb_Timestamp,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test); ok {
-						res = pdata.CreatedAt
-					} else if data, ok := p.Source.(Test); ok {
-						res = data.CreatedAt
-					}
-					return res, nil
-				},
-			},
-			"lastSession": &graphql.Field{
-				Type: graphql.Scalar_durationpb_Duration,
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test); ok {
-						res = pdata.LastSession
-					} else if data, ok := p.Source.(Test); ok {
-						res = data.LastSession
-					}
-					return res, nil
-				},
-			},
-			"attributes": &graphql.Field{
-				Type: graphql.NewList(Input_Test_AttributesEntry),
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*Test); ok {
-						res = pdata.Attributes
-					} else if data, ok := p.Source.(Test); ok {
-						res = data.Attributes
-					}
-					return res, nil
-				},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tbl := NewSymbolTable(f)
+			opts := DefaultOptions()
+			opts.Nullability = c.nullability
+			g := p.NewGeneratedFile("test.graphql.pb.go", f.GoImportPath)
+			v := NewVisitor(tbl, f, g, f.GoImportPath.String(), opts)
+			v.VisitMessage(tbl.Root(), msg, c.typ)
+			b, err := v.Content()
+			if err != nil {
+				t.Fatalf("failed to generate file: %s", err.Error())
+			}
+			res := strings.Join(strings.Fields(string(b)), " ")
+			for _, want := range c.want {
+				if !strings.Contains(res, want) {
+					t.Errorf("expected %q in:\n%s", want, res)
+				}
+			}
+			if strings.Contains(res, "Union") {
+				t.Errorf("unexpected union for the optional field in:\n%s", res)
+			}
+		})
+	}
+}
+
+func TestVisitRecursion(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	messages := make(map[string]*protogen.Message)
+	for _, m := range f.Messages {
+		messages[m.GoIdent.GoName] = m
+	}
+	cases := []struct {
+		name    string
+		message string
+		typ     GQLType
+		want    []string
+	}{
+		{
+			name:    "self object",
+			message: "TestTreeNode",
+			typ:     GQLTypeObject,
+			want: []string{
+				"var Object_TestTreeNode *graphql.Object func init() { Object_TestTreeNode = graphql.NewObject(",
+				"Fields: graphql.FieldsThunk(func() graphql.Fields { return graphql.Fields{",
+				"\"parent\": &graphql.Field{ Type: Object_TestTreeNode,",
+				"\"children\": &graphql.Field{ Type: graphql.NewList(Object_TestTreeNode),",
 			},
 		},
-	},
-)
-var Object_TestRepeated *graphql.Object = graphql.NewObject(
-	graphql.ObjectConfig{
-		Name: "Object_TestRepeated",
-		IsTypeOf: func(g graphql.IsTypeOfParams) bool {
-			return true
-		},
-		Fields: graphql.Fields{
-			"tags": &graphql.Field{
-				Type: graphql.NewList(graphql.String),
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestRepeated); ok {
-						res = pdata.Tags
-					} else if data, ok := p.Source.(TestRepeated); ok {
-						res = data.Tags
-					}
-					return res, nil
-				},
-			},
-			"failedAttempts": &graphql.Field{
-				Type: graphql.NewList(graphql.Scalar_timestamppb_Timestamp),
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestRepeated); ok {
-						res = pdata.FailedAttempts
-					} else if data, ok := p.Source.(TestRepeated); ok {
-						res = data.FailedAttempts
-					}
-					return res, nil
-				},
-			},
-			"history": &graphql.Field{
-				Type: graphql.NewList(Enum_TestRepeated_History),
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestRepeated); ok {
-						res = pdata.History
-					} else if data, ok := p.Source.(TestRepeated); ok {
-						res = data.History
-					}
-					return res, nil
-				},
+		{
+			name:    "self input",
+			message: "TestTreeNode",
+			typ:     GQLTypeInput,
+			want: []string{
+				"var Input_TestTreeNode *graphql.InputObject func init() { Input_TestTreeNode = graphql.NewInputObject(",
+				"Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap { return graphql.InputObjectConfigFieldMap{",
+				"\"parent\": &graphql.InputObjectFieldConfig{ Type: Input_TestTreeNode, },",
 			},
 		},
-	},
-)
-var Input_TestRepeated *graphql.InputObject = graphql.NewInputObject(
-	graphql.InputObjectConfig{
-		Name: "Input_TestRepeated",
-		IsTypeOf: func(g graphql.IsTypeOfParams) bool {
-			return true
-		},
-		Fields: graphql.InputObjectConfigFieldMap{
-			"tags": &graphql.Field{
-				Type: graphql.NewList(graphql.String),
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestRepeated); ok {
-						res = pdata.Tags
-					} else if data, ok := p.Source.(TestRepeated); ok {
-						res = data.Tags
-					}
-					return res, nil
-				},
-			},
-			"failedAttempts": &graphql.Field{
-				Type: graphql.NewList(graphql.Scalar_timestamppb_Timestamp),
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestRepeated); ok {
-						res = pdata.FailedAttempts
-					} else if data, ok := p.Source.(TestRepeated); ok {
-						res = data.FailedAttempts
-					}
-					return res, nil
-				},
-			},
-			"history": &graphql.Field{
-				Type: graphql.NewList(Enum_TestRepeated_History),
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					var res interface{}
-					if pdata, ok := p.Source.(*TestRepeated); ok {
-						res = pdata.History
-					} else if data, ok := p.Source.(TestRepeated); ok {
-						res = data.History
-					}
-					return res, nil
-				},
+		{
+			name:    "mutual object",
+			message: "TestThread",
+			typ:     GQLTypeObject,
+			want: []string{
+				"\"replies\": &graphql.Field{ Type: graphql.NewList(Object_TestReply),",
+				"var Object_TestReply *graphql.Object func init() {",
+				"\"thread\": &graphql.Field{ Type: Object_TestThread,",
 			},
 		},
-	},
-)
-
-func RegisterHelloTestServiceQueries(sc HelloTestServiceClient) error {
-	graphql.RegisterQuery("hello", &graphql.Field{
-		Name: "hello",
-		Args: graphql.FieldConfigArgument{
-			"input": &graphql.ArgumentConfig{
-				Type: Input_Test,
+		{
+			name:    "mutual input",
+			message: "TestThread",
+			typ:     GQLTypeInput,
+			want: []string{
+				"\"replies\": &graphql.InputObjectFieldConfig{ Type: graphql.NewList(Input_TestReply), },",
+				"var Input_TestReply *graphql.InputObject func init() {",
+				"\"thread\": &graphql.InputObjectFieldConfig{ Type: Input_TestThread, },",
 			},
 		},
-		Type: Object_Test,
-		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-			var req Test
-			rawJson, err := json.Marshal(p.Args["input"])
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tbl := NewSymbolTable(f)
+			g := p.NewGeneratedFile("test.graphql.pb.go", f.GoImportPath)
+			v := NewVisitor(tbl, f, g, f.GoImportPath.String(), DefaultOptions())
+			v.VisitMessage(tbl.Root(), messages[c.message], c.typ)
+			b, err := v.Content()
 			if err != nil {
-				return nil, err
+				t.Fatalf("failed to generate file: %s", err.Error())
 			}
-			err = json.Unmarshal(rawJson, &req)
-			if err != nil {
-				return nil, err
+			res := strings.Join(strings.Fields(string(b)), " ")
+			for _, want := range c.want {
+				if !strings.Contains(res, want) {
+					t.Errorf("expected %q in:\n%s", want, res)
+				}
 			}
-			return sc.HelloQuery(ctx, &req)
-		},
-	})
+			for _, m := range []string{"TestTreeNode", "TestThread", "TestReply"} {
+				if n := strings.Count(res, "var "+string(c.typ)+"_"+m+" "); n > 1 {
+					t.Errorf("expected %s to be declared once, got %d", m, n)
+				}
+			}
+		})
+	}
 }
 
-func RegisterHelloTestServiceMutations(sc HelloTestServiceClient) error {
-	graphql.RegisterMutation("mutateHello", &graphql.Field{
-		Name: "mutateHello",
-		Args: graphql.FieldConfigArgument{
-			"input": &graphql.ArgumentConfig{
-				Type: Input_Test,
-			},
-		},
-		Type: graphql.Scalar_emptypb_Empty,
-		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-			var req Test
-			rawJson, err := json.Marshal(p.Args["input"])
-			if err != nil {
-				return nil, err
-			}
-			err = json.Unmarshal(rawJson, &req)
-			if err != nil {
-				return nil, err
-			}
-			return sc.HelloMutation(ctx, &req)
-		},
-	})
+func TestVisitOneOfInput(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	opts := DefaultOptions()
+	opts.AllInputs = true
+	tbl := NewSymbolTable(f)
+	g := p.NewGeneratedFile("test.graphql.pb.go", f.GoImportPath)
+	v := NewVisitor(tbl, f, g, f.GoImportPath.String(), opts)
+	v.Visit(tbl.Root(), f)
+	b, err := v.Content()
+	if err != nil {
+		t.Fatalf("failed to generate file: %s", err.Error())
+	}
+	res := strings.Join(strings.Fields(string(b)), " ")
+	want := []string{
+		"var Input_TestOneOfInput_Target *graphql.InputObject func init() { Input_TestOneOfInput_Target = graphql.NewInputObject( graphql.InputObjectConfig{ Name: \"Input_TestOneOfInput_Target\", Description: \"target of the request\",",
+		"\"detail\": &graphql.InputObjectFieldConfig{ Type: Input_Test_TestDetail, },",
+		"\"mail\": &graphql.InputObjectFieldConfig{ Type: graphql.String, },",
+		"return graphql.InputObjectConfigFieldMap{ \"id\": &graphql.InputObjectFieldConfig{ Type: graphql.String, }, \"target\": &graphql.InputObjectFieldConfig{ Type: Input_TestOneOfInput_Target, Description: \"target of the request\", }, }",
+		"reg.OneOfInput(Input_TestOneOfInput_Target) reg.RenameInputField(Input_TestOneOfInput_Target, \"mail\", \"email\")",
+	}
+	for _, w := range want {
+		if !strings.Contains(res, w) {
+			t.Errorf("expected %q in:\n%s", w, res)
+		}
+	}
+	if strings.Contains(res, "var Input_Test_Error *graphql.Union") {
+		t.Errorf("expected no union for input oneofs:\n%s", res)
+	}
+	if strings.Contains(res, "RenameInputField(Input_TestOneOfInput,") {
+		t.Errorf("expected oneof members to be renamed by the oneof input:\n%s", res)
+	}
+}
+
+func TestVisitMixedOneOf(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	var msg *protogen.Message
+	for _, m := range f.Messages {
+		if m.GoIdent.GoName == "TestMixedOneof" {
+			msg = m
+		}
+	}
+	tbl := NewSymbolTable(f)
+	g := p.NewGeneratedFile("test.graphql.pb.go", f.GoImportPath)
+	v := NewVisitor(tbl, f, g, f.GoImportPath.String(), DefaultOptions())
+	v.VisitMessage(tbl.Root(), msg, GQLTypeObject)
+	b, err := v.Content()
+	if err != nil {
+		t.Fatalf("failed to generate file: %s", err.Error())
+	}
+	res := strings.Join(strings.Fields(string(b)), " ")
+	want := []string{
+		"var Object_TestMixedOneof_Text *graphql.Object = graphql.NewObject(graphql.ObjectConfig{ Name: \"Object_TestMixedOneof_Text\", Fields: graphql.Fields{ \"value\": &graphql.Field{ Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) { if pdata, ok := p.Source.(*TestMixedOneof_Text); ok { return pdata.Text, nil } return nil, nil }, }, }, })",
+		"Name: \"Object_TestMixedOneof_Number\", Description: \"numeric value\", Fields: graphql.Fields{ \"value\": &graphql.Field{ Type: graphql.Int,",
+		"\"value\": &graphql.Field{ Type: Enum_UserStatus, Resolve: func(p graphql.ResolveParams) (interface{}, error) { if pdata, ok := p.Source.(*TestMixedOneof_Status); ok { return pdata.Status.String(), nil }",
+		"\"value\": &graphql.Field{ Type: graphql1.Scalar_timestamppb_Timestamp,",
+		"return []*graphql.Object{ Object_TestMixedOneof_Text, Object_TestMixedOneof_Number, Object_TestMixedOneof_Status, Object_Test_TestDetail, Object_TestMixedOneof_At, }",
+		"case *TestMixedOneof_Text: return Object_TestMixedOneof_Text",
+		"case *Test_TestDetail: return Object_Test_TestDetail",
+		"case *TestMixedOneof_At: return Object_TestMixedOneof_At",
+		"if d, ok := data.(*TestMixedOneof_Status); ok { return d, nil }",
+		"if d, ok := data.(*TestMixedOneof_Detail); ok { return d.Detail, nil }",
+	}
+	for _, w := range want {
+		if !strings.Contains(res, w) {
+			t.Errorf("expected %q in:\n%s", w, res)
+		}
+	}
 }
-`
+
+func TestVisitFlattenArgs(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	services := make(map[string]*protogen.Service)
+	for _, svc := range f.Services {
+		services[svc.GoName] = svc
+	}
 	cases := []struct {
-		name       string
-		message    *protogen.Service
-		wantString string
+		name        string
+		service     string
+		flattenArgs bool
+		want        []string
+		notWant     []string
 	}{
-		// {
-		// 	name:       "no grapqhl methods",
-		// 	message:    f.Services[0],
-		// 	wantString: wantEmptyString,
-		// },
 		{
-			name:       "positive",
-			message:    f.Services[1],
-			wantString: wantSvcString,
+			name:    "rpc option",
+			service: "FlattenService",
+			want: []string{
+				"reg.RegisterQuery(\"flattenFields\", &graphql.Field{ Name: \"flattenFields\", Args: graphql.FieldConfigArgument{ \"id\": &graphql.ArgumentConfig{ Type: graphql.NewNonNull(graphql.String), Description: \"identifier\", }, \"secret\": &graphql.ArgumentConfig{ Type: graphql.String, }, \"newName\": &graphql.ArgumentConfig{ Type: graphql.String, }, \"tags\": &graphql.ArgumentConfig{ Type: graphql.NewNonNull(graphql.NewList(graphql.String)), }, \"userStatus\": &graphql.ArgumentConfig{ Type: Enum_UserStatus, }, },",
+				"rawJson, err := reg.MarshalInput(Input_TestFieldOptions, p.Args)",
+				"reg.RegisterMutation(\"flattenOneof\", &graphql.Field{ Name: \"flattenOneof\", Args: graphql.FieldConfigArgument{ \"id\": &graphql.ArgumentConfig{ Type: graphql.String, }, \"target\": &graphql.ArgumentConfig{ Type: Input_TestOneOfInput_Target, Description: \"target of the request\", }, },",
+				"reg.RegisterQuery(\"keepInput\", &graphql.Field{ Name: \"keepInput\", Args: graphql.FieldConfigArgument{ \"input\": &graphql.ArgumentConfig{ Type: Input_TestRenamed, }, },",
+				"rawJson, err := reg.MarshalInput(Input_TestRenamed, p.Args[\"input\"])",
+			},
+			notWant: []string{"\"computed\": &graphql.ArgumentConfig", "\"labels\": &graphql.ArgumentConfig"},
+		},
+		{
+			name:        "parameter",
+			service:     "HelloTestService",
+			flattenArgs: true,
+			want: []string{
+				"reg.RegisterQuery(\"hello\", &graphql.Field{ Name: \"hello\", Args: graphql.FieldConfigArgument{ \"name\": &graphql.ArgumentConfig{ Type: graphql.String, }, \"maybeString\": &graphql.ArgumentConfig{ Type: graphql1.Input_wrapperspb_StringValue, },",
+				"\"attributes\": &graphql.ArgumentConfig{ Type: graphql1.Scalar_JSON, }, \"error\": &graphql.ArgumentConfig{ Type: Input_Test_Error, }, },",
+				"rawJson, err := reg.MarshalInput(Input_Test, p.Args)",
+			},
+			notWant: []string{"p.Args[\"input\"]"},
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			g := p.NewGeneratedFile("test.graphql.pb.go", p.FilesByPath["test.proto"].GoImportPath)
-			v := NewVisitor(f, g, p.Files[0].GoImportPath.String())
-			for _, m := range f.Messages {
-				v.VisitMessage(root, m, GQLTypeObject)
-				v.VisitMessage(root, m, GQLTypeInput)
-			}
-			v.VisitService(root, c.message)
-			res, err := v.Content()
+			opts := DefaultOptions()
+			opts.FlattenArgs = c.flattenArgs
+			tbl := NewSymbolTable(f)
+			g := p.NewGeneratedFile("test.graphql.pb.go", f.GoImportPath)
+			v := NewVisitor(tbl, f, g, f.GoImportPath.String(), opts)
+			v.VisitService(tbl.Root(), services[c.service])
+			b, err := v.Content()
 			if err != nil {
 				t.Fatalf("failed to generate file: %s", err.Error())
 			}
-			fmt.Println(string(res))
-			// if res := cmp.Diff(c.wantString, string(res)); res != "" {
-			// 	t.Error(res)
-			// }
+			res := strings.Join(strings.Fields(string(b)), " ")
+			for _, w := range c.want {
+				if !strings.Contains(res, w) {
+					t.Errorf("expected %q in:\n%s", w, res)
+				}
+			}
+			for _, w := range c.notWant {
+				if strings.Contains(res, w) {
+					t.Errorf("unexpected %q in:\n%s", w, res)
+				}
+			}
 		})
 	}
 }
+
+func TestVisitResponseField(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	tbl := NewSymbolTable(f)
+	g := p.NewGeneratedFile("test.graphql.pb.go", f.GoImportPath)
+	v := NewVisitor(tbl, f, g, f.GoImportPath.String(), DefaultOptions())
+	for _, svc := range f.Services {
+		if svc.GoName == "UnwrapService" {
+			v.VisitService(tbl.Root(), svc)
+		}
+	}
+	b, err := v.Content()
+	if err != nil {
+		t.Fatalf("failed to generate file: %s", err.Error())
+	}
+	res := strings.Join(strings.Fields(string(b)), " ")
+	want := []string{
+		"Type: Object_Test, Resolve: func(p graphql.ResolveParams) (interface{}, error) {",
+		"res, err = sc.UnwrapData(p.Context, &req, graphql1.CallOptions(p.Context)...) if err != nil { return nil, reg.MapError(p.Context, err) } return res.GetData(), nil",
+		"Type: Object_Test_TestDetail, Resolve:",
+		"return res.GetData().GetDetail(), nil",
+		"Type: graphql.NewList(Object_Test), Resolve:",
+		"return res.GetItems(), nil",
+		"Type: Enum_UserStatus, Subscribe:",
+		"msg = res.GetStatus().String()",
+		"stream, err := sc.UnwrapStatus(p.Context, &req) if err != nil { return nil, reg.MapError(p.Context, err) }",
+		"} else if err != nil { msg = reg.MapError(p.Context, err) }",
+	}
+	for _, w := range want {
+		if !strings.Contains(res, w) {
+			t.Errorf("expected %q in:\n%s", w, res)
+		}
+	}
+	var test *protogen.Message
+	for _, m := range f.Messages {
+		if m.GoIdent.GoName == "TestEnvelope" {
+			test = m
+		}
+	}
+	for _, path := range []string{"missing", "data.missing", "items.name", "status.name", "labels", "data.detail.name.first"} {
+		if _, err := responseFields(test, path); !errors.Is(err, ErrResponseField) {
+			t.Errorf("expected %s error for %q, got %v", ErrResponseField, path, err)
+		}
+	}
+}
+
+func TestGolden(t *testing.T) {
+	f, ok := p.FilesByPath["test.proto"]
+	if !ok {
+		panic("failed to read FileDescriptor")
+	}
+	generate := func() map[string]string {
+		tbl := NewSymbolTable(f)
+		g := p.NewGeneratedFile("test_graphql.pb.go", f.GoImportPath)
+		v := NewVisitor(tbl, f, g, f.GoImportPath.String(), DefaultOptions())
+		v.Visit(tbl.Root(), f)
+		b, err := v.Content()
+		if err != nil {
+			t.Fatalf("failed to generate file: %s", err.Error())
+		}
+		s := NewSDL()
+		v.VisitSDL(s)
+		return map[string]string{
+			"test_graphql.pb.go.golden": string(b),
+			"test.graphql.golden":       s.String() + "\n",
+		}
+	}
+	res := generate()
+	if diff := cmp.Diff(res, generate()); diff != "" {
+		t.Fatalf("expected the same output from every run:\n%s", diff)
+	}
+	for name, content := range res {
+		path := filepath.Join("testdata", name)
+		if *update {
+			if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to update %s: %s", path, err.Error())
+			}
+			continue
+		}
+		want, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s, run the tests with -update to create it: %s", path, err.Error())
+		}
+		if diff := cmp.Diff(string(want), content); diff != "" {
+			t.Errorf("%s is outdated, run the tests with -update to regenerate it:\n%s", path, diff)
+		}
+	}
+}
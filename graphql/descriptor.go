@@ -0,0 +1,91 @@
+package graphql
+
+import (
+	"fmt"
+
+	. "github.com/graphql-go/graphql"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var (
+	ErrMissingConnection error = fmt.Errorf("missing connection")
+)
+
+// RegisterDescriptorSet registers the graphql operations of the services
+// described in fds, such as the output of `protoc -o`. conns maps fully
+// qualified service names to the connection serving them.
+func (r *Registry) RegisterDescriptorSet(fds *descriptorpb.FileDescriptorSet, conns map[string]grpc.ClientConnInterface) error {
+	files, err := newFiles(fds)
+	if err != nil {
+		return err
+	}
+	var rangeErr error
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			sd := services.Get(i)
+			if !hasOperations(sd) {
+				continue
+			}
+			conn, ok := conns[string(sd.FullName())]
+			if !ok {
+				rangeErr = fmt.Errorf("%w for service: %s", ErrMissingConnection, sd.FullName())
+				return false
+			}
+			if rangeErr = r.RegisterService(sd, conn); rangeErr != nil {
+				return false
+			}
+		}
+		return true
+	})
+	return rangeErr
+}
+
+// NewSchemaFromDescriptorSet builds a schema from the services described in
+// fds, without generated code
+func NewSchemaFromDescriptorSet(fds *descriptorpb.FileDescriptorSet, conns map[string]grpc.ClientConnInterface) (*Schema, error) {
+	reg := NewRegistry()
+	if err := reg.RegisterDescriptorSet(fds, conns); err != nil {
+		return nil, err
+	}
+	return reg.Schema()
+}
+
+func hasOperations(sd protoreflect.ServiceDescriptor) bool {
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		opt, ok := proto.GetExtension(methods.Get(i).Options(), E_Type).(*GraphQLOption)
+		if ok && opt != nil && opt.GetType() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// newFiles creates the file registry of fds. Dependencies missing from fds
+// are taken from the local registry when available, otherwise descriptors
+// referencing them become placeholders.
+func newFiles(fds *descriptorpb.FileDescriptorSet) (*protoregistry.Files, error) {
+	set := &descriptorpb.FileDescriptorSet{File: fds.GetFile()}
+	known := make(map[string]bool)
+	for _, fd := range set.File {
+		known[fd.GetName()] = true
+	}
+	for i := 0; i < len(set.File); i++ {
+		for _, dep := range set.File[i].GetDependency() {
+			if known[dep] {
+				continue
+			}
+			known[dep] = true
+			if local, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+				set.File = append(set.File, protodesc.ToFileDescriptorProto(local))
+			}
+		}
+	}
+	return protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(set)
+}
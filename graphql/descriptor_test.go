@@ -0,0 +1,29 @@
+package graphql
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestNewSchemaFromDescriptorSet(t *testing.T) {
+	conn := startGreeterServer(t)
+	// dependencies are left out as with `protoc -o` without --include_imports
+	fds := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{greeterFileDescriptorProto()},
+	}
+	schema, err := NewSchemaFromDescriptorSet(fds, map[string]grpc.ClientConnInterface{
+		"edgetest.Greeter": conn,
+	})
+	if err != nil {
+		t.Fatalf("failed to create schema: %s", err.Error())
+	}
+	testGreeterSchema(t, schema)
+
+	_, err = NewSchemaFromDescriptorSet(fds, map[string]grpc.ClientConnInterface{})
+	if !errors.Is(err, ErrMissingConnection) {
+		t.Errorf("expected %v, got %v", ErrMissingConnection, err)
+	}
+}
@@ -0,0 +1,780 @@
+package graphql
+
+import (
+	"fmt"
+	"io"
+
+	. "github.com/graphql-go/graphql"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var (
+	ErrInvalidOperation error = fmt.Errorf("invalid graphql operation")
+	ErrExclusiveOptions       = fmt.Errorf("graphql input_only and output_only options are exclusive")
+)
+
+// dynamicInputSuffix is appended to the names of the dynamic input types, as
+// the generator does by default
+const dynamicInputSuffix = "Input"
+
+var wellKnownOutputs map[protoreflect.FullName]Output = map[protoreflect.FullName]Output{
+	"google.protobuf.Empty":         Scalar_emptypb_Empty,
+	"google.protobuf.Timestamp":     Scalar_timestamppb_Timestamp,
+	"google.protobuf.Duration":      Scalar_durationpb_Duration,
+	"google.protobuf.BoolValue":     Object_wrapperspb_BoolValue,
+	"google.protobuf.StringValue":   Object_wrapperspb_StringValue,
+	"google.protobuf.FloatValue":    Object_wrapperspb_FloatValue,
+	"google.protobuf.DoubleValue":   Object_wrapperspb_DoubleValue,
+	"google.protobuf.Int64Value":    Object_wrapperspb_Int64Value,
+	"google.protobuf.Int32Value":    Object_wrapperspb_Int32Value,
+	"google.protobuf.UInt64Value":   Object_wrapperspb_UInt64Value,
+	"google.protobuf.UInt32Value":   Object_wrapperspb_UInt32Value,
+	"google.protobuf.SInt64Value":   Object_wrapperspb_SInt64Value,
+	"google.protobuf.SInt32Value":   Object_wrapperspb_SInt32Value,
+	"google.protobuf.Fixed64Value":  Object_wrapperspb_Fixed64Value,
+	"google.protobuf.Fixed32Value":  Object_wrapperspb_Fixed32Value,
+	"google.protobuf.SFixed64Value": Object_wrapperspb_SFixed64Value,
+	"google.protobuf.SFixed32Value": Object_wrapperspb_SFixed32Value,
+}
+
+var wellKnownInputs map[protoreflect.FullName]Input = map[protoreflect.FullName]Input{
+	"google.protobuf.Empty":         Scalar_emptypb_Empty,
+	"google.protobuf.Timestamp":     Scalar_timestamppb_Timestamp,
+	"google.protobuf.Duration":      Scalar_durationpb_Duration,
+	"google.protobuf.BoolValue":     Input_wrapperspb_BoolValue,
+	"google.protobuf.StringValue":   Input_wrapperspb_StringValue,
+	"google.protobuf.FloatValue":    Input_wrapperspb_FloatValue,
+	"google.protobuf.DoubleValue":   Input_wrapperspb_DoubleValue,
+	"google.protobuf.Int64Value":    Input_wrapperspb_Int64Value,
+	"google.protobuf.Int32Value":    Input_wrapperspb_Int32Value,
+	"google.protobuf.UInt64Value":   Input_wrapperspb_UInt64Value,
+	"google.protobuf.UInt32Value":   Input_wrapperspb_UInt32Value,
+	"google.protobuf.SInt64Value":   Input_wrapperspb_SInt64Value,
+	"google.protobuf.SInt32Value":   Input_wrapperspb_SInt32Value,
+	"google.protobuf.Fixed64Value":  Input_wrapperspb_Fixed64Value,
+	"google.protobuf.Fixed32Value":  Input_wrapperspb_Fixed32Value,
+	"google.protobuf.SFixed64Value": Input_wrapperspb_SFixed64Value,
+	"google.protobuf.SFixed32Value": Input_wrapperspb_SFixed32Value,
+}
+
+// WellKnownOutput returns the output type of this package which represents
+// the well known protobuf message name
+func WellKnownOutput(name protoreflect.FullName) (Output, bool) {
+	t, ok := wellKnownOutputs[name]
+	return t, ok
+}
+
+// WellKnownInput returns the input type of this package which represents the
+// well known protobuf message name
+func WellKnownInput(name protoreflect.FullName) (Input, bool) {
+	t, ok := wellKnownInputs[name]
+	return t, ok
+}
+
+// RegisterService registers the graphql operations declared with
+// `(graphql.type)` method options of a gRPC service. Requests and responses
+// are handled as dynamic messages, so no generated code is needed.
+//
+// The types follow the graphql options of the messages, fields and enums as
+// the generated types do. They are named as with the `package` naming of the
+// generator, e.g. `SampleHelloResponse`, since there is no go identifier to
+// name them after, and types of different proto declarations sharing a name
+// are rejected with ErrNameCollision.
+func (r *Registry) RegisterService(sd protoreflect.ServiceDescriptor, conn grpc.ClientConnInterface) error {
+	b := &dynamicBuilder{reg: r}
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		typ, name, err := Operation(md)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			continue
+		}
+		if err := checkMethod(md); err != nil {
+			return err
+		}
+		method := fmt.Sprintf("/%s/%s", sd.FullName(), md.Name())
+		switch typ {
+		case OperationQuery:
+			err = r.RegisterQuery(name, b.unaryField(name, md, method, conn))
+		case OperationMutation:
+			err = r.RegisterMutation(name, b.unaryField(name, md, method, conn))
+		case OperationSubscription:
+			err = r.RegisterSubscription(name, b.streamField(name, md, method, conn))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return b.build()
+}
+
+// checkMethod fails for the rpc(s) whose input or response has no graphql
+// type
+func checkMethod(md protoreflect.MethodDescriptor) error {
+	if IsHidden(md.Input(), true) {
+		return fmt.Errorf("%w for method: %s: input must not be skipped or output only", ErrInvalidOperation, md.FullName())
+	}
+	fields, err := ResponseFields(md.Output(), MethodOption(md).GetResponseField())
+	switch {
+	case err != nil:
+		return fmt.Errorf("%w for method: %s", err, md.FullName())
+	case len(fields) == 0 && IsHidden(md.Output(), false):
+		return fmt.Errorf("%w for method: %s: output must not be skipped or input only", ErrInvalidOperation, md.FullName())
+	case len(fields) > 0 && IsSkipped(fields[len(fields)-1], false):
+		return fmt.Errorf("%w for method: %s: response field must not be skipped", ErrInvalidOperation, md.FullName())
+	}
+	return nil
+}
+
+type dynamicBuilder struct {
+	reg *Registry
+	// pending are the object and input types whose fields are not built yet
+	pending []Type
+	err     error
+}
+
+// dynamicMember is the value of a oneof member held by a wrapper object type
+type dynamicMember struct {
+	field protoreflect.FieldDescriptor
+	value interface{}
+}
+
+func (b *dynamicBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// build builds the fields of the new types, and of the types they reference,
+// so that their errors are returned when registering the service
+func (b *dynamicBuilder) build() error {
+	for len(b.pending) > 0 {
+		switch t := b.pending[0].(type) {
+		case *Object:
+			t.Fields()
+		case *InputObject:
+			t.Fields()
+		}
+		b.pending = b.pending[1:]
+	}
+	return b.err
+}
+
+// lookup returns the type built for the proto declaration owner
+func (b *dynamicBuilder) lookup(name, owner string) (Type, bool) {
+	t, ok := b.reg.LookupType(name)
+	if !ok || b.reg.typeOwners[name] != owner {
+		return nil, false
+	}
+	return t, true
+}
+
+// register registers the type built for the proto declaration owner, unless
+// its name is invalid or already taken
+func (b *dynamicBuilder) register(t Type, owner string) {
+	name := t.Name()
+	_, exists := b.reg.LookupType(name)
+	other := ""
+	switch {
+	case !IsValidName(name):
+		b.fail(fmt.Errorf("%w: %q of %s", ErrInvalidName, name, owner))
+		return
+	case IsReservedName(name):
+		other = "built-in type"
+	case exists && b.reg.typeOwners[name] != "":
+		other = b.reg.typeOwners[name]
+	case exists:
+		other = "registered type"
+	}
+	if other != "" {
+		b.fail(fmt.Errorf("%w: %q of %s and %s", ErrNameCollision, name, other, owner))
+		return
+	}
+	b.reg.RegisterType(t)
+	b.reg.typeOwners[name] = owner
+	b.pending = append(b.pending, t)
+}
+
+// typeName returns the graphql name of the type of a message or an enum
+func typeName(d protoreflect.Descriptor, input bool) string {
+	name := NameOption(d)
+	if name == "" {
+		name = BaseName(d, true)
+	}
+	if input {
+		name += dynamicInputSuffix
+	}
+	return name
+}
+
+// oneofName returns the graphql name of the union or the input type of a
+// oneof
+func oneofName(od protoreflect.OneofDescriptor, input bool) string {
+	name := typeName(od.Parent(), false) + PascalCase(string(od.Name()))
+	if input {
+		name += dynamicInputSuffix
+	}
+	return name
+}
+
+// skipped reports whether the field is hidden from the object or the input
+// type, failing for messages with exclusive options
+func (b *dynamicBuilder) skipped(fd protoreflect.FieldDescriptor, input bool) bool {
+	if md := fd.Message(); md != nil {
+		if opt := MessageOption(md); opt.GetInputOnly() && opt.GetOutputOnly() {
+			b.fail(fmt.Errorf("%w: %s", ErrExclusiveOptions, md.FullName()))
+		}
+	}
+	return IsSkipped(fd, input)
+}
+
+func (b *dynamicBuilder) args(md protoreflect.MethodDescriptor, input Input) FieldConfigArgument {
+	if !flattensArgs(md) {
+		return FieldConfigArgument{
+			"input": &ArgumentConfig{
+				Type: input,
+			},
+		}
+	}
+	args := FieldConfigArgument{}
+	for name, f := range b.inputFields(md.Input(), input.(*InputObject)) {
+		args[name] = &ArgumentConfig{
+			Type:        f.Type,
+			Description: f.Description,
+		}
+	}
+	return args
+}
+
+// flattensArgs reports whether the fields of the rpc input are arguments of
+// the operation, which the dynamic schema only does with the option of the rpc
+func flattensArgs(md protoreflect.MethodDescriptor) bool {
+	_, isWellKnown := wellKnownInputs[md.Input().FullName()]
+	return !isWellKnown && MethodOption(md).GetFlattenArgs()
+}
+
+// request decodes the rpc input from the `input` argument, or from all the
+// arguments when they are flattened, like the generated resolvers do
+func (b *dynamicBuilder) request(md protoreflect.MethodDescriptor, input Input, args map[string]interface{}) (*dynamicpb.Message, error) {
+	var value interface{} = args["input"]
+	if flattensArgs(md) {
+		value = args
+	}
+	req := dynamicpb.NewMessage(md.Input())
+	rawJson, err := b.reg.MarshalInput(input, value)
+	if err != nil {
+		return nil, err
+	}
+	err = protojson.Unmarshal(rawJson, req)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// operation returns the field of an operation, without its resolvers
+func (b *dynamicBuilder) operation(name string, md protoreflect.MethodDescriptor) *Field {
+	opt := MethodOption(md)
+	field := &Field{
+		Name:              name,
+		Description:       Description("", md),
+		DeprecationReason: Deprecation(opt.GetDeprecationReason(), md),
+		Args:              b.args(md, b.input(md.Input())),
+		Type:              b.output(md.Output()),
+	}
+	if fields, _ := ResponseFields(md.Output(), opt.GetResponseField()); len(fields) > 0 {
+		field.Type = b.outputFieldType(fields[len(fields)-1])
+	}
+	return field
+}
+
+// response returns the rpc output, or the value of its response field which
+// is the zero value of the field when a message along the path is unset
+func response(md protoreflect.MethodDescriptor, res protoreflect.Message) interface{} {
+	fields, _ := ResponseFields(md.Output(), MethodOption(md).GetResponseField())
+	if len(fields) == 0 {
+		return dynamicMessage(res.Interface())
+	}
+	for _, fd := range fields[:len(fields)-1] {
+		res = res.Get(fd).Message()
+	}
+	return dynamicField(res, fields[len(fields)-1])
+}
+
+func (b *dynamicBuilder) unaryField(name string, md protoreflect.MethodDescriptor, method string, conn grpc.ClientConnInterface) *Field {
+	input := b.input(md.Input())
+	field := b.operation(name, md)
+	field.Resolve = func(p ResolveParams) (interface{}, error) {
+		req, err := b.request(md, input, p.Args)
+		if err != nil {
+			return nil, err
+		}
+		res := dynamicpb.NewMessage(md.Output())
+		err = conn.Invoke(p.Context, method, req, res, CallOptions(p.Context)...)
+		if err != nil {
+			return nil, b.reg.MapError(p.Context, err)
+		}
+		return response(md, res), nil
+	}
+	return field
+}
+
+func (b *dynamicBuilder) streamField(name string, md protoreflect.MethodDescriptor, method string, conn grpc.ClientConnInterface) *Field {
+	desc := &grpc.StreamDesc{
+		StreamName:    string(md.Name()),
+		ServerStreams: true,
+	}
+	input := b.input(md.Input())
+	field := b.operation(name, md)
+	field.Subscribe = func(p ResolveParams) (interface{}, error) {
+		req, err := b.request(md, input, p.Args)
+		if err != nil {
+			return nil, err
+		}
+		// no CallOptions: the HTTP headers of a subscription are
+		// written before its stream returns any response metadata
+		stream, err := conn.NewStream(p.Context, desc, method)
+		if err != nil {
+			return nil, b.reg.MapError(p.Context, err)
+		}
+		if err := stream.SendMsg(req); err != nil {
+			return nil, b.reg.MapError(p.Context, err)
+		}
+		if err := stream.CloseSend(); err != nil {
+			return nil, b.reg.MapError(p.Context, err)
+		}
+		ch := make(chan interface{})
+		go func() {
+			defer close(ch)
+			for {
+				var msg interface{}
+				res := dynamicpb.NewMessage(md.Output())
+				err := stream.RecvMsg(res)
+				if err == io.EOF {
+					return
+				} else if err != nil {
+					msg = b.reg.MapError(p.Context, err)
+				} else {
+					msg = response(md, res)
+				}
+				select {
+				case ch <- msg:
+				case <-p.Context.Done():
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+		return ch, nil
+	}
+	field.Resolve = func(p ResolveParams) (interface{}, error) {
+		if err, ok := p.Source.(error); ok {
+			return nil, err
+		}
+		return p.Source, nil
+	}
+	return field
+}
+
+func (b *dynamicBuilder) enum(ed protoreflect.EnumDescriptor) *Enum {
+	name := typeName(ed, false)
+	owner := fmt.Sprintf("enum %s", ed.FullName())
+	if t, ok := b.lookup(name, owner); ok {
+		return t.(*Enum)
+	}
+	values := EnumValueConfigMap{}
+	for i := 0; i < ed.Values().Len(); i++ {
+		val := ed.Values().Get(i)
+		opt := EnumValueOption(val)
+		values[EnumValueName(val)] = &EnumValueConfig{
+			Value:             string(val.Name()),
+			Description:       Description(opt.GetDescription(), val),
+			DeprecationReason: Deprecation(opt.GetDeprecationReason(), val),
+		}
+	}
+	enum := NewEnum(EnumConfig{
+		Name:        name,
+		Description: TypeDescription(ed),
+		Values:      values,
+	})
+	b.register(enum, owner)
+	return enum
+}
+
+func (b *dynamicBuilder) output(md protoreflect.MessageDescriptor) Output {
+	if t, ok := wellKnownOutputs[md.FullName()]; ok {
+		return t
+	}
+	name := typeName(md, false)
+	owner := fmt.Sprintf("object %s", md.FullName())
+	if t, ok := b.lookup(name, owner); ok {
+		return t.(Output)
+	}
+	obj := NewObject(ObjectConfig{
+		Name:        name,
+		Description: TypeDescription(md),
+		IsTypeOf: func(p IsTypeOfParams) bool {
+			return true
+		},
+		Fields: FieldsThunk(func() Fields {
+			return b.outputFields(md)
+		}),
+	})
+	b.register(obj, owner)
+	return obj
+}
+
+func (b *dynamicBuilder) outputFields(md protoreflect.MessageDescriptor) Fields {
+	fields := Fields{}
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if IsOneofMember(fd) || b.skipped(fd, false) {
+			continue
+		}
+		opt := FieldOption(fd)
+		fields[FieldName(fd)] = &Field{
+			Name:              FieldName(fd),
+			Description:       Description(opt.GetDescription(), fd),
+			DeprecationReason: Deprecation(opt.GetDeprecationReason(), fd),
+			Type:              b.outputFieldType(fd),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				m, ok := p.Source.(protoreflect.ProtoMessage)
+				if !ok {
+					return nil, nil
+				}
+				return dynamicField(m.ProtoReflect(), fd), nil
+			},
+		}
+	}
+	for i := 0; i < md.Oneofs().Len(); i++ {
+		od := md.Oneofs().Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+		union := b.union(od)
+		if union == nil {
+			continue
+		}
+		fields[string(od.Name())] = &Field{
+			Name:        string(od.Name()),
+			Description: TypeDescription(od),
+			Type:        union,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				m, ok := p.Source.(protoreflect.ProtoMessage)
+				if !ok {
+					return nil, nil
+				}
+				fd := m.ProtoReflect().WhichOneof(od)
+				switch {
+				case fd == nil || IsSkipped(fd, false):
+					return nil, nil
+				case IsWrappedMember(fd):
+					return dynamicMember{fd, dynamicField(m.ProtoReflect(), fd)}, nil
+				}
+				return dynamicField(m.ProtoReflect(), fd), nil
+			},
+		}
+	}
+	return fields
+}
+
+// outputType returns the type of the field values
+func (b *dynamicBuilder) outputType(fd protoreflect.FieldDescriptor) Output {
+	switch {
+	case fd.IsMap():
+		return Scalar_JSON
+	case fd.Enum() != nil:
+		return b.enum(fd.Enum())
+	case fd.Message() != nil:
+		return b.output(fd.Message())
+	}
+	return scalarType(fd.Kind())
+}
+
+// outputFieldType returns the type of the field, a list for repeated fields
+// and non null with the required option
+func (b *dynamicBuilder) outputFieldType(fd protoreflect.FieldDescriptor) Output {
+	typ := b.outputType(fd)
+	if fd.IsList() {
+		typ = NewList(typ)
+	}
+	if FieldOption(fd).GetRequired() {
+		typ = NewNonNull(typ)
+	}
+	return typ
+}
+
+// union returns the union type of the members of a oneof, or nil when all of
+// them are skipped. The members which are not objects are held by wrapper
+// objects
+func (b *dynamicBuilder) union(od protoreflect.OneofDescriptor) *Union {
+	name := oneofName(od, false)
+	owner := fmt.Sprintf("oneof %s", od.FullName())
+	if t, ok := b.lookup(name, owner); ok {
+		return t.(*Union)
+	}
+	objects := make(map[protoreflect.FullName]*Object)
+	wrappers := make(map[protoreflect.FullName]*Object)
+	types := make([]*Object, 0)
+	for i := 0; i < od.Fields().Len(); i++ {
+		fd := od.Fields().Get(i)
+		if b.skipped(fd, false) {
+			continue
+		}
+		var obj *Object
+		if IsWrappedMember(fd) {
+			obj = b.wrapper(name, fd)
+			wrappers[fd.FullName()] = obj
+		} else {
+			obj = b.output(fd.Message()).(*Object)
+			objects[fd.Message().FullName()] = obj
+		}
+		types = append(types, obj)
+	}
+	if len(types) == 0 {
+		return nil
+	}
+	union := NewUnion(UnionConfig{
+		Name:        name,
+		Description: TypeDescription(od),
+		Types:       types,
+		ResolveType: func(p ResolveTypeParams) *Object {
+			switch v := p.Value.(type) {
+			case dynamicMember:
+				return wrappers[v.field.FullName()]
+			case protoreflect.ProtoMessage:
+				return objects[v.ProtoReflect().Descriptor().FullName()]
+			}
+			return nil
+		},
+	})
+	b.register(union, owner)
+	return union
+}
+
+// wrapper returns the object type holding the value of a oneof member which
+// is not an object, as graphql unions only have object members
+func (b *dynamicBuilder) wrapper(union string, fd protoreflect.FieldDescriptor) *Object {
+	name := union + PascalCase(string(fd.Name()))
+	owner := fmt.Sprintf("oneof member %s", fd.FullName())
+	if t, ok := b.lookup(name, owner); ok {
+		return t.(*Object)
+	}
+	opt := FieldOption(fd)
+	obj := NewObject(ObjectConfig{
+		Name:        name,
+		Description: Description(opt.GetDescription(), fd),
+		Fields: Fields{
+			"value": &Field{
+				Type:              b.outputType(fd),
+				DeprecationReason: Deprecation(opt.GetDeprecationReason(), fd),
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if v, ok := p.Source.(dynamicMember); ok {
+						return v.value, nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+	b.register(obj, owner)
+	return obj
+}
+
+func (b *dynamicBuilder) input(md protoreflect.MessageDescriptor) Input {
+	if t, ok := wellKnownInputs[md.FullName()]; ok {
+		return t
+	}
+	name := typeName(md, true)
+	owner := fmt.Sprintf("input %s", md.FullName())
+	if t, ok := b.lookup(name, owner); ok {
+		return t.(Input)
+	}
+	var input *InputObject
+	input = NewInputObject(InputObjectConfig{
+		Name:        name,
+		Description: TypeDescription(md),
+		Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+			return b.inputFields(md, input)
+		}),
+	})
+	b.register(input, owner)
+	return input
+}
+
+// inputFields returns the fields of the input type of a message, declaring
+// the renamed ones to the registry. The members of a oneof are fields of the
+// input type of the oneof
+func (b *dynamicBuilder) inputFields(md protoreflect.MessageDescriptor, input *InputObject) InputObjectConfigFieldMap {
+	fields := InputObjectConfigFieldMap{}
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if IsOneofMember(fd) || b.skipped(fd, true) {
+			continue
+		}
+		b.inputField(fields, input, fd)
+	}
+	for i := 0; i < md.Oneofs().Len(); i++ {
+		od := md.Oneofs().Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+		if oneOf := b.oneOfInput(od); oneOf != nil {
+			fields[string(od.Name())] = &InputObjectFieldConfig{
+				Type:        oneOf,
+				Description: TypeDescription(od),
+			}
+		}
+	}
+	return fields
+}
+
+func (b *dynamicBuilder) inputField(fields InputObjectConfigFieldMap, input *InputObject, fd protoreflect.FieldDescriptor) {
+	name := FieldName(fd)
+	fields[name] = &InputObjectFieldConfig{
+		Type:        b.inputFieldType(fd),
+		Description: Description(FieldOption(fd).GetDescription(), fd),
+	}
+	if name != fd.JSONName() {
+		b.reg.RenameInputField(input, name, fd.JSONName())
+	}
+}
+
+// oneOfInput returns the input type of a oneof, of which exactly one field
+// must be set, or nil when all of its members are skipped
+func (b *dynamicBuilder) oneOfInput(od protoreflect.OneofDescriptor) *InputObject {
+	name := oneofName(od, true)
+	owner := fmt.Sprintf("input oneof %s", od.FullName())
+	if t, ok := b.lookup(name, owner); ok {
+		return t.(*InputObject)
+	}
+	members := make([]protoreflect.FieldDescriptor, 0)
+	for i := 0; i < od.Fields().Len(); i++ {
+		if fd := od.Fields().Get(i); !b.skipped(fd, true) {
+			members = append(members, fd)
+		}
+	}
+	if len(members) == 0 {
+		return nil
+	}
+	var input *InputObject
+	input = NewInputObject(InputObjectConfig{
+		Name:        name,
+		Description: TypeDescription(od),
+		Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+			fields := InputObjectConfigFieldMap{}
+			for _, fd := range members {
+				b.inputField(fields, input, fd)
+			}
+			return fields
+		}),
+	})
+	b.reg.OneOfInput(input)
+	b.register(input, owner)
+	return input
+}
+
+func (b *dynamicBuilder) inputFieldType(fd protoreflect.FieldDescriptor) Input {
+	var typ Input
+	switch {
+	case fd.IsMap():
+		typ = Scalar_JSON
+	case fd.Enum() != nil:
+		typ = b.enum(fd.Enum())
+	case fd.Message() != nil:
+		typ = b.input(fd.Message())
+	default:
+		typ = scalarType(fd.Kind())
+	}
+	if fd.IsList() {
+		typ = NewList(typ)
+	}
+	if FieldOption(fd).GetRequired() {
+		typ = NewNonNull(typ)
+	}
+	return typ
+}
+
+func scalarType(kind protoreflect.Kind) *Scalar {
+	switch kind {
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return Float
+	case protoreflect.BoolKind:
+		return Boolean
+	case protoreflect.BytesKind:
+		return Scalar_bytes
+	case protoreflect.StringKind:
+		return String
+	default:
+		return Int
+	}
+}
+
+func dynamicField(m protoreflect.Message, fd protoreflect.FieldDescriptor) interface{} {
+	switch {
+	case fd.IsMap():
+		res := make(map[string]interface{})
+		m.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			res[k.String()] = dynamicValue(fd.MapValue(), v)
+			return true
+		})
+		return res
+	case fd.IsList():
+		list := m.Get(fd).List()
+		res := make([]interface{}, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			res[i] = dynamicValue(fd, list.Get(i))
+		}
+		return res
+	case fd.Message() != nil && !m.Has(fd):
+		return nil
+	default:
+		return dynamicValue(fd, m.Get(fd))
+	}
+}
+
+func dynamicValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if val := fd.Enum().Values().ByNumber(v.Enum()); val != nil {
+			return string(val.Name())
+		}
+		return int32(v.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return dynamicMessage(v.Message().Interface())
+	default:
+		return v.Interface()
+	}
+}
+
+// dynamicMessage converts well known types to their generated go type which
+// are understood by the scalars and objects of this package
+func dynamicMessage(m proto.Message) interface{} {
+	name := m.ProtoReflect().Descriptor().FullName()
+	if _, ok := wellKnownOutputs[name]; !ok {
+		return m
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(name)
+	if err != nil {
+		return m
+	}
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return m
+	}
+	res := mt.New().Interface()
+	if err := proto.Unmarshal(b, res); err != nil {
+		return m
+	}
+	return res
+}
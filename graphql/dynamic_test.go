@@ -0,0 +1,619 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	. "github.com/graphql-go/graphql"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	greeterOnce sync.Once
+	greeterFile protoreflect.FileDescriptor
+	greeterTime = time.Unix(1600000000, 0)
+)
+
+func greeterMethodOptions(opt *GraphQLOption) *descriptorpb.MethodOptions {
+	opts := &descriptorpb.MethodOptions{}
+	proto.SetExtension(opts, E_Type, opt)
+	return opts
+}
+
+func greeterField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string, repeated bool) *descriptorpb.FieldDescriptorProto {
+	label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	if repeated {
+		label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	}
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Label:  label.Enum(),
+		Type:   typ.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+// greeterFileDescriptorProto describes the following service:
+//
+//	enum Mood { NEUTRAL = 0; HAPPY = 1; }
+//	message HelloRequest { string name = 1; int32 count = 2; }
+//	message HelloResponse {
+//	    string message = 1;
+//	    Mood mood = 2;
+//	    google.protobuf.Timestamp created_at = 3;
+//	    repeated string tags = 4;
+//	    HelloRequest request = 5;
+//	}
+//	service Greeter {
+//	    rpc Hello(HelloRequest) returns (HelloResponse) { option (graphql.type) = { query: "hello" }; }
+//	    rpc SetHello(HelloRequest) returns (HelloResponse) { option (graphql.type) = { mutation: "setHello" }; }
+//	    rpc Watch(HelloRequest) returns (stream HelloResponse) { option (graphql.type) = { subscription: "watch" }; }
+//	    rpc Internal(HelloRequest) returns (HelloResponse);
+//	    rpc Unexposed(HelloRequest) returns (HelloResponse) { option (graphql.type) = {}; }
+//	}
+func greeterFileDescriptorProto() *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("edgetest/greeter.proto"),
+		Package:    proto.String("edgetest"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"graphql/graphql.proto", "google/protobuf/timestamp.proto"},
+		EnumType: []*descriptorpb.EnumDescriptorProto{
+			{
+				Name: proto.String("Mood"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("NEUTRAL"), Number: proto.Int32(0)},
+					{Name: proto.String("HAPPY"), Number: proto.Int32(1)},
+				},
+			},
+		},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("HelloRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					greeterField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false),
+					greeterField("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, "", false),
+				},
+			},
+			{
+				Name: proto.String("HelloResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					greeterField("message", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false),
+					greeterField("mood", 2, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".edgetest.Mood", false),
+					greeterField("created_at", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp", false),
+					greeterField("tags", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", true),
+					greeterField("request", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".edgetest.HelloRequest", false),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("Greeter"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name:       proto.String("Hello"),
+						InputType:  proto.String(".edgetest.HelloRequest"),
+						OutputType: proto.String(".edgetest.HelloResponse"),
+						Options:    greeterMethodOptions(&GraphQLOption{Type: &GraphQLOption_Query{Query: "hello"}}),
+					},
+					{
+						Name:       proto.String("SetHello"),
+						InputType:  proto.String(".edgetest.HelloRequest"),
+						OutputType: proto.String(".edgetest.HelloResponse"),
+						Options:    greeterMethodOptions(&GraphQLOption{Type: &GraphQLOption_Mutation{Mutation: "setHello"}}),
+					},
+					{
+						Name:            proto.String("Watch"),
+						InputType:       proto.String(".edgetest.HelloRequest"),
+						OutputType:      proto.String(".edgetest.HelloResponse"),
+						ServerStreaming: proto.Bool(true),
+						Options:         greeterMethodOptions(&GraphQLOption{Type: &GraphQLOption_Subscription{Subscription: "watch"}}),
+					},
+					{
+						Name:       proto.String("Internal"),
+						InputType:  proto.String(".edgetest.HelloRequest"),
+						OutputType: proto.String(".edgetest.HelloResponse"),
+					},
+					{
+						Name:       proto.String("Unexposed"),
+						InputType:  proto.String(".edgetest.HelloRequest"),
+						OutputType: proto.String(".edgetest.HelloResponse"),
+						Options:    greeterMethodOptions(&GraphQLOption{}),
+					},
+				},
+			},
+		},
+	}
+}
+
+func greeterFileDescriptor(t *testing.T) protoreflect.FileDescriptor {
+	greeterOnce.Do(func() {
+		fd, err := protodesc.NewFile(greeterFileDescriptorProto(), protoregistry.GlobalFiles)
+		if err != nil {
+			t.Fatalf("failed to create file descriptor: %s", err.Error())
+		}
+		if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+			t.Fatalf("failed to register file descriptor: %s", err.Error())
+		}
+		greeterFile = fd
+	})
+	return greeterFile
+}
+
+func greeterResponse(md protoreflect.MessageDescriptor, req *dynamicpb.Message, i int) *dynamicpb.Message {
+	fields := md.Fields()
+	reqName := req.Get(req.Descriptor().Fields().ByName("name")).String()
+	res := dynamicpb.NewMessage(md)
+	res.Set(fields.ByName("message"), protoreflect.ValueOfString("hello "+reqName))
+	res.Set(fields.ByName("mood"), protoreflect.ValueOfEnum(1))
+	res.Set(fields.ByName("created_at"), protoreflect.ValueOfMessage(timestamppb.New(greeterTime.Add(time.Duration(i)*time.Second)).ProtoReflect()))
+	tags := res.Mutable(fields.ByName("tags")).List()
+	tags.Append(protoreflect.ValueOfString("a"))
+	tags.Append(protoreflect.ValueOfString("b"))
+	res.Set(fields.ByName("request"), protoreflect.ValueOfMessage(req))
+	return res
+}
+
+// startGreeterServer serves the greeter service along with the server
+// reflection service over an in-memory connection
+func startGreeterServer(t *testing.T) *grpc.ClientConn {
+	fd := greeterFileDescriptor(t)
+	svc := fd.Services().ByName("Greeter")
+	reqDesc := fd.Messages().ByName("HelloRequest")
+	resDesc := fd.Messages().ByName("HelloResponse")
+	unary := func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+		req := dynamicpb.NewMessage(reqDesc)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		return greeterResponse(resDesc, req, 0), nil
+	}
+	s := grpc.NewServer()
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: string(svc.FullName()),
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{MethodName: "Hello", Handler: unary},
+			{MethodName: "SetHello", Handler: unary},
+			{MethodName: "Internal", Handler: unary},
+		},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    "Watch",
+				ServerStreams: true,
+				Handler: func(srv interface{}, stream grpc.ServerStream) error {
+					req := dynamicpb.NewMessage(reqDesc)
+					if err := stream.RecvMsg(req); err != nil {
+						return err
+					}
+					count := int(req.Get(reqDesc.Fields().ByName("count")).Int())
+					for i := 0; i < count; i++ {
+						if err := stream.SendMsg(greeterResponse(resDesc, req, i)); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			},
+		},
+		Metadata: fd.Path(),
+	}, struct{}{})
+	reflection.Register(s)
+	lis := bufconn.Listen(1024 * 1024)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial greeter server: %s", err.Error())
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func testGreeterSchema(t *testing.T, schema *Schema) {
+	createdAt := func(i int) string {
+		return greeterTime.Add(time.Duration(i) * time.Second).Format(jsISOString)
+	}
+	res := Do(Params{
+		Schema:        *schema,
+		RequestString: `{ hello(input: {name: "edge"}) { message mood createdAt tags request { name } } }`,
+		Context:       context.Background(),
+	})
+	want := map[string]interface{}{
+		"hello": map[string]interface{}{
+			"message":   "hello edge",
+			"mood":      "HAPPY",
+			"createdAt": createdAt(0),
+			"tags":      []interface{}{"a", "b"},
+			"request":   map[string]interface{}{"name": "edge"},
+		},
+	}
+	if res.HasErrors() {
+		t.Fatalf("unexpected errors: %v", res.Errors)
+	}
+	if diff := cmp.Diff(want, res.Data); diff != "" {
+		t.Error(diff)
+	}
+	if schema.MutationType().Fields()["setHello"] == nil {
+		t.Error("expected setHello mutation")
+	}
+	results := Subscribe(Params{
+		Schema:        *schema,
+		RequestString: `subscription { watch(input: {name: "edge", count: 2}) { createdAt } }`,
+		Context:       context.Background(),
+	})
+	i := 0
+	for res := range results {
+		if res.HasErrors() {
+			t.Fatalf("unexpected errors: %v", res.Errors)
+		}
+		want := map[string]interface{}{
+			"watch": map[string]interface{}{"createdAt": createdAt(i)},
+		}
+		if diff := cmp.Diff(want, res.Data); diff != "" {
+			t.Error(diff)
+		}
+		i++
+	}
+	if i != 2 {
+		t.Errorf("expected 2 subscription results, got %d", i)
+	}
+}
+
+func TestNewSchemaFromReflection(t *testing.T) {
+	conn := startGreeterServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	schema, err := NewSchemaFromReflection(ctx, conn)
+	if err != nil {
+		t.Fatalf("failed to create schema: %s", err.Error())
+	}
+	testGreeterSchema(t, schema)
+}
+
+// echoConn answers every unary rpc with its request
+type echoConn struct{}
+
+func (echoConn) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	proto.Merge(reply.(proto.Message), args.(proto.Message))
+	return nil
+}
+
+func (echoConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, io.EOF
+}
+
+func queryService(pkg string, messages []*descriptorpb.DescriptorProto) *descriptorpb.FileDescriptorProto {
+	msg := "." + pkg + "." + messages[0].GetName()
+	return &descriptorpb.FileDescriptorProto{
+		Name:        proto.String(pkg + "/service.proto"),
+		Package:     proto.String(pkg),
+		Syntax:      proto.String("proto3"),
+		MessageType: messages,
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("Service"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name:       proto.String("Get"),
+						InputType:  proto.String(msg),
+						OutputType: proto.String(msg),
+						Options:    greeterMethodOptions(&GraphQLOption{Type: &GraphQLOption_Query{Query: strings.ReplaceAll(pkg, ".", "_")}}),
+					},
+				},
+			},
+		},
+	}
+}
+
+func registerTestService(reg *Registry, fdp *descriptorpb.FileDescriptorProto) error {
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		return err
+	}
+	return reg.RegisterService(fd.Services().Get(0), echoConn{})
+}
+
+func TestRegisterServiceNames(t *testing.T) {
+	hello := func(opts *descriptorpb.MessageOptions) []*descriptorpb.DescriptorProto {
+		return []*descriptorpb.DescriptorProto{
+			{
+				Name:    proto.String("Hello"),
+				Field:   []*descriptorpb.FieldDescriptorProto{greeterField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false)},
+				Options: opts,
+			},
+		}
+	}
+	reg := NewRegistry()
+	for _, pkg := range []string{"a.v1", "b.v1"} {
+		if err := registerTestService(reg, queryService(pkg, hello(nil))); err != nil {
+			t.Fatalf("failed to register %s: %s", pkg, err.Error())
+		}
+	}
+	for _, name := range []string{"AV1Hello", "AV1HelloInput", "BV1Hello", "BV1HelloInput"} {
+		if _, ok := reg.LookupType(name); !ok {
+			t.Errorf("expected type %s", name)
+		}
+	}
+	if _, err := reg.Schema(); err != nil {
+		t.Fatalf("failed to create schema: %s", err.Error())
+	}
+
+	// the same declaration served by several upstreams shares its types
+	if err := registerTestService(reg, queryService("a.v1", hello(nil))); !errors.Is(err, ErrDuplicateQuery) {
+		t.Errorf("expected %v, got %v", ErrDuplicateQuery, err)
+	}
+
+	named := &descriptorpb.MessageOptions{}
+	proto.SetExtension(named, E_Object, &GraphQLMessageOption{Name: proto.String("Hello")})
+	reg = NewRegistry()
+	if err := registerTestService(reg, queryService("a.v1", hello(named))); err != nil {
+		t.Fatalf("failed to register a.v1: %s", err.Error())
+	}
+	err := registerTestService(reg, queryService("b.v1", hello(named)))
+	if !errors.Is(err, ErrNameCollision) {
+		t.Fatalf("expected %v, got %v", ErrNameCollision, err)
+	}
+	want := `"HelloInput" of input a.v1.Hello and input b.v1.Hello`
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("expected %q in %q", want, err.Error())
+	}
+}
+
+// optionsFileDescriptorProto describes the following service:
+//
+//	enum Status {
+//	    option (graphql.enum) = { name: "State" };
+//	    ACTIVE = 0 [(graphql.enum_value) = { name: "ON", description: "running" }];
+//	    OLD = 1 [deprecated = true];
+//	}
+//	message Secret { option (graphql.object) = { skip: true }; string value = 1; }
+//	message Item {
+//	    option (graphql.object) = { description: "an item" };
+//	    string id = 1 [(graphql.field) = { required: true }];
+//	    string display_name = 2 [(graphql.field) = { name: "label" }];
+//	    string internal = 3 [(graphql.field) = { skip: SKIP_ALL }];
+//	    Secret secret = 4;
+//	    string old = 5 [deprecated = true];
+//	    oneof kind {
+//	        string code = 6 [(graphql.field) = { name: "newCode" }];
+//	        Status status = 7;
+//	        Item parent = 8;
+//	    }
+//	}
+//	service Items {
+//	    rpc Get(Item) returns (Item) { option (graphql.type) = { query: "item" }; }
+//	    rpc Label(Item) returns (Item) { option (graphql.type) = { query: "label", response_field: "display_name" }; }
+//	    rpc Create(Item) returns (Item) { option (graphql.type) = { mutation: "create", flatten_args: true }; }
+//	}
+func optionsFileDescriptorProto() *descriptorpb.FileDescriptorProto {
+	fieldOptions := func(opt *GraphQLFieldOption, deprecated bool) *descriptorpb.FieldOptions {
+		opts := &descriptorpb.FieldOptions{}
+		if deprecated {
+			opts.Deprecated = proto.Bool(true)
+		}
+		if opt != nil {
+			proto.SetExtension(opts, E_Field, opt)
+		}
+		return opts
+	}
+	field := func(f *descriptorpb.FieldDescriptorProto, opts *descriptorpb.FieldOptions, oneof bool) *descriptorpb.FieldDescriptorProto {
+		f.Options = opts
+		if oneof {
+			f.OneofIndex = proto.Int32(0)
+		}
+		return f
+	}
+	enumOpts := &descriptorpb.EnumOptions{}
+	proto.SetExtension(enumOpts, E_Enum, &GraphQLEnumOption{Name: proto.String("State")})
+	activeOpts := &descriptorpb.EnumValueOptions{}
+	proto.SetExtension(activeOpts, E_EnumValue, &GraphQLEnumValueOption{Name: proto.String("ON"), Description: proto.String("running")})
+	secretOpts := &descriptorpb.MessageOptions{}
+	proto.SetExtension(secretOpts, E_Object, &GraphQLMessageOption{Skip: proto.Bool(true)})
+	itemOpts := &descriptorpb.MessageOptions{}
+	proto.SetExtension(itemOpts, E_Object, &GraphQLMessageOption{Description: proto.String("an item")})
+	method := func(name string, opt *GraphQLOption) *descriptorpb.MethodDescriptorProto {
+		return &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String(".edgeopts.Item"),
+			OutputType: proto.String(".edgeopts.Item"),
+			Options:    greeterMethodOptions(opt),
+		}
+	}
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("edgeopts/items.proto"),
+		Package:    proto.String("edgeopts"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"graphql/graphql.proto"},
+		EnumType: []*descriptorpb.EnumDescriptorProto{
+			{
+				Name: proto.String("Status"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("ACTIVE"), Number: proto.Int32(0), Options: activeOpts},
+					{Name: proto.String("OLD"), Number: proto.Int32(1), Options: &descriptorpb.EnumValueOptions{Deprecated: proto.Bool(true)}},
+				},
+				Options: enumOpts,
+			},
+		},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name:    proto.String("Secret"),
+				Field:   []*descriptorpb.FieldDescriptorProto{greeterField("value", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false)},
+				Options: secretOpts,
+			},
+			{
+				Name: proto.String("Item"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field(greeterField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false), fieldOptions(&GraphQLFieldOption{Required: proto.Bool(true)}, false), false),
+					field(greeterField("display_name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false), fieldOptions(&GraphQLFieldOption{Name: proto.String("label")}, false), false),
+					field(greeterField("internal", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false), fieldOptions(&GraphQLFieldOption{Skip: GraphQLSkip_SKIP_ALL.Enum()}, false), false),
+					greeterField("secret", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".edgeopts.Secret", false),
+					field(greeterField("old", 5, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false), fieldOptions(nil, true), false),
+					field(greeterField("code", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false), fieldOptions(&GraphQLFieldOption{Name: proto.String("newCode")}, false), true),
+					field(greeterField("status", 7, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".edgeopts.Status", false), nil, true),
+					field(greeterField("parent", 8, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".edgeopts.Item", false), nil, true),
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("kind")}},
+				Options:   itemOpts,
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("Items"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					method("Get", &GraphQLOption{Type: &GraphQLOption_Query{Query: "item"}}),
+					method("Label", &GraphQLOption{Type: &GraphQLOption_Query{Query: "label"}, ResponseField: proto.String("display_name")}),
+					method("Create", &GraphQLOption{Type: &GraphQLOption_Mutation{Mutation: "create"}, FlattenArgs: proto.Bool(true)}),
+				},
+			},
+		},
+	}
+}
+
+func TestRegisterServiceOptions(t *testing.T) {
+	reg := NewRegistry()
+	if err := registerTestService(reg, optionsFileDescriptorProto()); err != nil {
+		t.Fatalf("failed to register service: %s", err.Error())
+	}
+	schema, err := reg.Schema()
+	if err != nil {
+		t.Fatalf("failed to create schema: %s", err.Error())
+	}
+
+	item, ok := schema.Type("EdgeoptsItem").(*Object)
+	if !ok {
+		t.Fatalf("expected object EdgeoptsItem, got %v", schema.Type("EdgeoptsItem"))
+	}
+	if item.Description() != "an item" {
+		t.Errorf("unexpected description %q", item.Description())
+	}
+	fields := item.Fields()
+	names := make([]string, 0)
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if diff := cmp.Diff([]string{"id", "kind", "label", "old"}, names); diff != "" {
+		t.Errorf("unexpected fields: %s", diff)
+	}
+	if _, ok := fields["id"].Type.(*NonNull); !ok {
+		t.Errorf("expected non null id, got %s", fields["id"].Type)
+	}
+	if fields["old"].DeprecationReason != DefaultDeprecationReason {
+		t.Errorf("expected deprecated old field, got %q", fields["old"].DeprecationReason)
+	}
+	kind, ok := fields["kind"].Type.(*Union)
+	if !ok || kind.Name() != "EdgeoptsItemKind" {
+		t.Fatalf("expected union EdgeoptsItemKind, got %s", fields["kind"].Type)
+	}
+	members := make([]string, 0)
+	for _, obj := range kind.Types() {
+		members = append(members, obj.Name())
+	}
+	if diff := cmp.Diff([]string{"EdgeoptsItemKindCode", "EdgeoptsItemKindStatus", "EdgeoptsItem"}, members); diff != "" {
+		t.Errorf("unexpected union members: %s", diff)
+	}
+	state, ok := schema.Type("State").(*Enum)
+	if !ok {
+		t.Fatalf("expected enum State, got %v", schema.Type("State"))
+	}
+	for _, val := range state.Values() {
+		switch val.Name {
+		case "ON":
+			if val.Description != "running" {
+				t.Errorf("unexpected description %q", val.Description)
+			}
+		case "OLD":
+			if val.DeprecationReason != DefaultDeprecationReason {
+				t.Errorf("expected deprecated OLD value, got %q", val.DeprecationReason)
+			}
+		default:
+			t.Errorf("unexpected enum value %s", val.Name)
+		}
+	}
+	if schema.Type("EdgeoptsSecret") != nil || schema.Type("EdgeoptsSecretInput") != nil {
+		t.Error("expected no type for the skipped message")
+	}
+
+	cases := []struct {
+		query string
+		want  map[string]interface{}
+		err   bool
+	}{
+		{
+			query: `{ item(input: {id: "1", label: "a", kind: {newCode: "x"}}) { id label kind { ... on EdgeoptsItemKindCode { value } } } }`,
+			want: map[string]interface{}{
+				"item": map[string]interface{}{"id": "1", "label": "a", "kind": map[string]interface{}{"value": "x"}},
+			},
+		},
+		{
+			query: `{ item(input: {id: "1", kind: {status: OLD}}) { kind { ... on EdgeoptsItemKindStatus { value } } } }`,
+			want: map[string]interface{}{
+				"item": map[string]interface{}{"kind": map[string]interface{}{"value": "OLD"}},
+			},
+		},
+		{
+			query: `{ item(input: {id: "1", kind: {parent: {id: "2"}}}) { kind { ... on EdgeoptsItem { id } } } }`,
+			want: map[string]interface{}{
+				"item": map[string]interface{}{"kind": map[string]interface{}{"id": "2"}},
+			},
+		},
+		{
+			query: `{ label(input: {id: "1", label: "a"}) }`,
+			want:  map[string]interface{}{"label": "a"},
+		},
+		{
+			query: `mutation { create(id: "1", label: "a", kind: {newCode: "x"}) { label } }`,
+			want:  map[string]interface{}{"create": map[string]interface{}{"label": "a"}},
+		},
+		{
+			query: `{ item(input: {id: "1", kind: {newCode: "x", status: ON}}) { id } }`,
+			err:   true,
+		},
+	}
+	for _, c := range cases {
+		res := Do(Params{
+			Schema:        *schema,
+			RequestString: c.query,
+			Context:       context.Background(),
+		})
+		if c.err {
+			if !res.HasErrors() {
+				t.Errorf("%s: expected an error, got %v", c.query, res.Data)
+			}
+			continue
+		}
+		if res.HasErrors() {
+			t.Errorf("%s: unexpected errors: %v", c.query, res.Errors)
+			continue
+		}
+		if diff := cmp.Diff(c.want, res.Data); diff != "" {
+			t.Errorf("%s: %s", c.query, diff)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	"context"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorMapper converts the error of an upstream rpc into the error returned
+// to graphql clients. Errors implementing gqlerrors.ExtendedError are
+// formatted with their extensions
+type ErrorMapper func(ctx context.Context, err error) error
+
+// StatusError is the graphql error of a failed rpc, its extensions hold the
+// gRPC status code and the decoded status details
+type StatusError struct {
+	status *status.Status
+}
+
+func NewStatusError(s *status.Status) *StatusError {
+	return &StatusError{s}
+}
+
+func (e *StatusError) Error() string {
+	return e.status.Message()
+}
+
+// Status returns the gRPC status of the failed rpc
+func (e *StatusError) Status() *status.Status {
+	return e.status
+}
+
+// Extensions returns the `code` name and the numeric `grpcStatus` of the
+// status, along with its BadRequest, ErrorInfo, RetryInfo and
+// LocalizedMessage details
+func (e *StatusError) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{
+		"code":       code.Code(e.status.Code()).String(),
+		"grpcStatus": int(e.status.Code()),
+	}
+	for _, detail := range e.status.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			violations := make([]interface{}, 0)
+			for _, v := range d.GetFieldViolations() {
+				violations = append(violations, map[string]interface{}{
+					"field":       v.GetField(),
+					"description": v.GetDescription(),
+				})
+			}
+			ext["fieldViolations"] = violations
+		case *errdetails.ErrorInfo:
+			ext["errorInfo"] = map[string]interface{}{
+				"reason":   d.GetReason(),
+				"domain":   d.GetDomain(),
+				"metadata": d.GetMetadata(),
+			}
+		case *errdetails.RetryInfo:
+			ext["retryDelay"] = d.GetRetryDelay().AsDuration().String()
+		case *errdetails.LocalizedMessage:
+			ext["localizedMessage"] = map[string]interface{}{
+				"locale":  d.GetLocale(),
+				"message": d.GetMessage(),
+			}
+		}
+	}
+	return ext
+}
+
+// MapStatusError is the default ErrorMapper, it converts gRPC status errors
+// into StatusError and returns the other errors as is
+func MapStatusError(ctx context.Context, err error) error {
+	if s, ok := status.FromError(err); ok && s != nil {
+		return NewStatusError(s)
+	}
+	return err
+}
@@ -0,0 +1,103 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	. "github.com/graphql-go/graphql"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestMapError(t *testing.T) {
+	s, err := status.New(codes.InvalidArgument, "invalid greeting").WithDetails(
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "name", Description: "must not be empty"},
+			},
+		},
+		&errdetails.ErrorInfo{Reason: "EMPTY_NAME", Domain: "edge.test", Metadata: map[string]string{"field": "name"}},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(1500 * time.Millisecond)},
+		&errdetails.LocalizedMessage{Locale: "id-ID", Message: "nama wajib diisi"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errCustom := errors.New("custom")
+	cases := []struct {
+		name    string
+		err     error
+		mapper  ErrorMapper
+		message string
+		want    map[string]interface{}
+	}{
+		{
+			name:    "status",
+			err:     s.Err(),
+			message: "invalid greeting",
+			want: map[string]interface{}{
+				"code":       "INVALID_ARGUMENT",
+				"grpcStatus": 3,
+				"fieldViolations": []interface{}{
+					map[string]interface{}{"field": "name", "description": "must not be empty"},
+				},
+				"errorInfo": map[string]interface{}{
+					"reason":   "EMPTY_NAME",
+					"domain":   "edge.test",
+					"metadata": map[string]string{"field": "name"},
+				},
+				"retryDelay":       "1.5s",
+				"localizedMessage": map[string]interface{}{"locale": "id-ID", "message": "nama wajib diisi"},
+			},
+		},
+		{
+			name:    "not a status",
+			err:     errors.New("broken pipe"),
+			message: "broken pipe",
+		},
+		{
+			name: "mapper",
+			err:  status.Error(codes.NotFound, "no greeting"),
+			mapper: func(ctx context.Context, err error) error {
+				if status.Code(err) == codes.NotFound {
+					return errCustom
+				}
+				return MapStatusError(ctx, err)
+			},
+			message: "custom",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			reg := NewRegistry()
+			if c.mapper != nil {
+				reg.SetErrorMapper(c.mapper)
+			}
+			reg.RegisterQuery("greeting", &Field{
+				Type: String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					return nil, reg.MapError(p.Context, c.err)
+				},
+			})
+			schema, err := reg.Schema()
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := Do(Params{Schema: *schema, RequestString: "{ greeting }", Context: context.Background()})
+			if len(res.Errors) != 1 {
+				t.Fatalf("expected an error, got %v", res.Errors)
+			}
+			if res.Errors[0].Message != c.message {
+				t.Errorf("expected message %q, got %q", c.message, res.Errors[0].Message)
+			}
+			if diff := cmp.Diff(c.want, res.Errors[0].Extensions); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -1,57 +1,193 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.17.3
 // source: graphql/graphql.proto
 
 package graphql
 
-import proto "github.com/golang/protobuf/proto"
-import fmt "fmt"
-import math "math"
-import descriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+type GraphQLSkip int32
+
+const (
+	GraphQLSkip_SKIP_NONE GraphQLSkip = 0
+	// hide the field from the object type
+	GraphQLSkip_SKIP_OUTPUT GraphQLSkip = 1
+	// hide the field from the input type
+	GraphQLSkip_SKIP_INPUT GraphQLSkip = 2
+	// hide the field from both the object and input types
+	GraphQLSkip_SKIP_ALL GraphQLSkip = 3
+)
+
+// Enum value maps for GraphQLSkip.
+var (
+	GraphQLSkip_name = map[int32]string{
+		0: "SKIP_NONE",
+		1: "SKIP_OUTPUT",
+		2: "SKIP_INPUT",
+		3: "SKIP_ALL",
+	}
+	GraphQLSkip_value = map[string]int32{
+		"SKIP_NONE":   0,
+		"SKIP_OUTPUT": 1,
+		"SKIP_INPUT":  2,
+		"SKIP_ALL":    3,
+	}
+)
+
+func (x GraphQLSkip) Enum() *GraphQLSkip {
+	p := new(GraphQLSkip)
+	*p = x
+	return p
+}
+
+func (x GraphQLSkip) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GraphQLSkip) Descriptor() protoreflect.EnumDescriptor {
+	return file_graphql_graphql_proto_enumTypes[0].Descriptor()
+}
+
+func (GraphQLSkip) Type() protoreflect.EnumType {
+	return &file_graphql_graphql_proto_enumTypes[0]
+}
+
+func (x GraphQLSkip) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *GraphQLSkip) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = GraphQLSkip(num)
+	return nil
+}
+
+// Deprecated: Use GraphQLSkip.Descriptor instead.
+func (GraphQLSkip) EnumDescriptor() ([]byte, []int) {
+	return file_graphql_graphql_proto_rawDescGZIP(), []int{0}
+}
 
 type GraphQLOption struct {
-	// Types that are valid to be assigned to Type:
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Type:
 	//	*GraphQLOption_Query
 	//	*GraphQLOption_Mutation
-	Type                 isGraphQLOption_Type `protobuf_oneof:"type"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	//	*GraphQLOption_Subscription
+	Type isGraphQLOption_Type `protobuf_oneof:"type"`
+	// deprecation_reason deprecates the operation, rpc(s) declared with the
+	// deprecated option are deprecated with a default reason
+	DeprecationReason *string `protobuf:"bytes,4,opt,name=deprecation_reason,json=deprecationReason" json:"deprecation_reason,omitempty"`
+	// flatten_args exposes the fields of the rpc input as arguments of the
+	// operation instead of a single `input` argument, overriding the
+	// `flatten_args` generator parameter
+	FlattenArgs *bool `protobuf:"varint,5,opt,name=flatten_args,json=flattenArgs" json:"flatten_args,omitempty"`
+	// response_field is the dot separated path of the field of the rpc output
+	// returned by the operation, e.g. `data` or `data.greeting`
+	ResponseField *string `protobuf:"bytes,6,opt,name=response_field,json=responseField" json:"response_field,omitempty"`
+}
+
+func (x *GraphQLOption) Reset() {
+	*x = GraphQLOption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graphql_graphql_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (m *GraphQLOption) Reset()         { *m = GraphQLOption{} }
-func (m *GraphQLOption) String() string { return proto.CompactTextString(m) }
-func (*GraphQLOption) ProtoMessage()    {}
+func (x *GraphQLOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphQLOption) ProtoMessage() {}
+
+func (x *GraphQLOption) ProtoReflect() protoreflect.Message {
+	mi := &file_graphql_graphql_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphQLOption.ProtoReflect.Descriptor instead.
 func (*GraphQLOption) Descriptor() ([]byte, []int) {
-	return fileDescriptor_graphql_bf0c669ee24265f1, []int{0}
+	return file_graphql_graphql_proto_rawDescGZIP(), []int{0}
 }
-func (m *GraphQLOption) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GraphQLOption.Unmarshal(m, b)
+
+func (m *GraphQLOption) GetType() isGraphQLOption_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
 }
-func (m *GraphQLOption) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GraphQLOption.Marshal(b, m, deterministic)
+
+func (x *GraphQLOption) GetQuery() string {
+	if x, ok := x.GetType().(*GraphQLOption_Query); ok {
+		return x.Query
+	}
+	return ""
 }
-func (dst *GraphQLOption) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GraphQLOption.Merge(dst, src)
+
+func (x *GraphQLOption) GetMutation() string {
+	if x, ok := x.GetType().(*GraphQLOption_Mutation); ok {
+		return x.Mutation
+	}
+	return ""
 }
-func (m *GraphQLOption) XXX_Size() int {
-	return xxx_messageInfo_GraphQLOption.Size(m)
+
+func (x *GraphQLOption) GetSubscription() string {
+	if x, ok := x.GetType().(*GraphQLOption_Subscription); ok {
+		return x.Subscription
+	}
+	return ""
 }
-func (m *GraphQLOption) XXX_DiscardUnknown() {
-	xxx_messageInfo_GraphQLOption.DiscardUnknown(m)
+
+func (x *GraphQLOption) GetDeprecationReason() string {
+	if x != nil && x.DeprecationReason != nil {
+		return *x.DeprecationReason
+	}
+	return ""
+}
+
+func (x *GraphQLOption) GetFlattenArgs() bool {
+	if x != nil && x.FlattenArgs != nil {
+		return *x.FlattenArgs
+	}
+	return false
 }
 
-var xxx_messageInfo_GraphQLOption proto.InternalMessageInfo
+func (x *GraphQLOption) GetResponseField() string {
+	if x != nil && x.ResponseField != nil {
+		return *x.ResponseField
+	}
+	return ""
+}
 
 type isGraphQLOption_Type interface {
 	isGraphQLOption_Type()
@@ -65,124 +201,615 @@ type GraphQLOption_Mutation struct {
 	Mutation string `protobuf:"bytes,2,opt,name=mutation,oneof"`
 }
 
+type GraphQLOption_Subscription struct {
+	Subscription string `protobuf:"bytes,3,opt,name=subscription,oneof"`
+}
+
 func (*GraphQLOption_Query) isGraphQLOption_Type() {}
 
 func (*GraphQLOption_Mutation) isGraphQLOption_Type() {}
 
-func (m *GraphQLOption) GetType() isGraphQLOption_Type {
-	if m != nil {
-		return m.Type
+func (*GraphQLOption_Subscription) isGraphQLOption_Type() {}
+
+type GraphQLMessageOption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name overrides the graphql type name of the message
+	Name *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// skip hides the message from both the object and input types
+	Skip *bool `protobuf:"varint,2,opt,name=skip" json:"skip,omitempty"`
+	// input_only only generates the input type of the message
+	InputOnly *bool `protobuf:"varint,3,opt,name=input_only,json=inputOnly" json:"input_only,omitempty"`
+	// output_only only generates the object type of the message
+	OutputOnly  *bool   `protobuf:"varint,4,opt,name=output_only,json=outputOnly" json:"output_only,omitempty"`
+	Description *string `protobuf:"bytes,5,opt,name=description" json:"description,omitempty"`
+}
+
+func (x *GraphQLMessageOption) Reset() {
+	*x = GraphQLMessageOption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graphql_graphql_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (m *GraphQLOption) GetQuery() string {
-	if x, ok := m.GetType().(*GraphQLOption_Query); ok {
-		return x.Query
+func (x *GraphQLMessageOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphQLMessageOption) ProtoMessage() {}
+
+func (x *GraphQLMessageOption) ProtoReflect() protoreflect.Message {
+	mi := &file_graphql_graphql_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphQLMessageOption.ProtoReflect.Descriptor instead.
+func (*GraphQLMessageOption) Descriptor() ([]byte, []int) {
+	return file_graphql_graphql_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GraphQLMessageOption) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
 	return ""
 }
 
-func (m *GraphQLOption) GetMutation() string {
-	if x, ok := m.GetType().(*GraphQLOption_Mutation); ok {
-		return x.Mutation
+func (x *GraphQLMessageOption) GetSkip() bool {
+	if x != nil && x.Skip != nil {
+		return *x.Skip
+	}
+	return false
+}
+
+func (x *GraphQLMessageOption) GetInputOnly() bool {
+	if x != nil && x.InputOnly != nil {
+		return *x.InputOnly
+	}
+	return false
+}
+
+func (x *GraphQLMessageOption) GetOutputOnly() bool {
+	if x != nil && x.OutputOnly != nil {
+		return *x.OutputOnly
+	}
+	return false
+}
+
+func (x *GraphQLMessageOption) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
 	}
 	return ""
 }
 
-// XXX_OneofFuncs is for the internal use of the proto package.
-func (*GraphQLOption) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
-	return _GraphQLOption_OneofMarshaler, _GraphQLOption_OneofUnmarshaler, _GraphQLOption_OneofSizer, []interface{}{
-		(*GraphQLOption_Query)(nil),
-		(*GraphQLOption_Mutation)(nil),
+type GraphQLFieldOption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name overrides the graphql field name
+	Name *string      `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Skip *GraphQLSkip `protobuf:"varint,2,opt,name=skip,enum=graphql.GraphQLSkip" json:"skip,omitempty"`
+	// required wraps the field type in a non null type
+	Required    *bool   `protobuf:"varint,3,opt,name=required" json:"required,omitempty"`
+	Description *string `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+	// deprecation_reason deprecates the field of the object type
+	DeprecationReason *string `protobuf:"bytes,5,opt,name=deprecation_reason,json=deprecationReason" json:"deprecation_reason,omitempty"`
+}
+
+func (x *GraphQLFieldOption) Reset() {
+	*x = GraphQLFieldOption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graphql_graphql_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
 }
 
-func _GraphQLOption_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
-	m := msg.(*GraphQLOption)
-	// type
-	switch x := m.Type.(type) {
-	case *GraphQLOption_Query:
-		b.EncodeVarint(1<<3 | proto.WireBytes)
-		b.EncodeStringBytes(x.Query)
-	case *GraphQLOption_Mutation:
-		b.EncodeVarint(2<<3 | proto.WireBytes)
-		b.EncodeStringBytes(x.Mutation)
-	case nil:
-	default:
-		return fmt.Errorf("GraphQLOption.Type has unexpected type %T", x)
+func (x *GraphQLFieldOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphQLFieldOption) ProtoMessage() {}
+
+func (x *GraphQLFieldOption) ProtoReflect() protoreflect.Message {
+	mi := &file_graphql_graphql_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func _GraphQLOption_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
-	m := msg.(*GraphQLOption)
-	switch tag {
-	case 1: // type.query
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
+// Deprecated: Use GraphQLFieldOption.ProtoReflect.Descriptor instead.
+func (*GraphQLFieldOption) Descriptor() ([]byte, []int) {
+	return file_graphql_graphql_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GraphQLFieldOption) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *GraphQLFieldOption) GetSkip() GraphQLSkip {
+	if x != nil && x.Skip != nil {
+		return *x.Skip
+	}
+	return GraphQLSkip_SKIP_NONE
+}
+
+func (x *GraphQLFieldOption) GetRequired() bool {
+	if x != nil && x.Required != nil {
+		return *x.Required
+	}
+	return false
+}
+
+func (x *GraphQLFieldOption) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *GraphQLFieldOption) GetDeprecationReason() string {
+	if x != nil && x.DeprecationReason != nil {
+		return *x.DeprecationReason
+	}
+	return ""
+}
+
+type GraphQLEnumOption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name overrides the graphql type name of the enum
+	Name *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// skip hides the enum, along with the fields of the enum type
+	Skip        *bool   `protobuf:"varint,2,opt,name=skip" json:"skip,omitempty"`
+	Description *string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+}
+
+func (x *GraphQLEnumOption) Reset() {
+	*x = GraphQLEnumOption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graphql_graphql_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GraphQLEnumOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphQLEnumOption) ProtoMessage() {}
+
+func (x *GraphQLEnumOption) ProtoReflect() protoreflect.Message {
+	mi := &file_graphql_graphql_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		x, err := b.DecodeStringBytes()
-		m.Type = &GraphQLOption_Query{x}
-		return true, err
-	case 2: // type.mutation
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphQLEnumOption.ProtoReflect.Descriptor instead.
+func (*GraphQLEnumOption) Descriptor() ([]byte, []int) {
+	return file_graphql_graphql_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GraphQLEnumOption) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *GraphQLEnumOption) GetSkip() bool {
+	if x != nil && x.Skip != nil {
+		return *x.Skip
+	}
+	return false
+}
+
+func (x *GraphQLEnumOption) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+type GraphQLEnumValueOption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name overrides the graphql name of the enum value
+	Name              *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Description       *string `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	DeprecationReason *string `protobuf:"bytes,3,opt,name=deprecation_reason,json=deprecationReason" json:"deprecation_reason,omitempty"`
+}
+
+func (x *GraphQLEnumValueOption) Reset() {
+	*x = GraphQLEnumValueOption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graphql_graphql_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GraphQLEnumValueOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphQLEnumValueOption) ProtoMessage() {}
+
+func (x *GraphQLEnumValueOption) ProtoReflect() protoreflect.Message {
+	mi := &file_graphql_graphql_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphQLEnumValueOption.ProtoReflect.Descriptor instead.
+func (*GraphQLEnumValueOption) Descriptor() ([]byte, []int) {
+	return file_graphql_graphql_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GraphQLEnumValueOption) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *GraphQLEnumValueOption) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *GraphQLEnumValueOption) GetDeprecationReason() string {
+	if x != nil && x.DeprecationReason != nil {
+		return *x.DeprecationReason
+	}
+	return ""
+}
+
+var file_graphql_graphql_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*GraphQLOption)(nil),
+		Field:         50001,
+		Name:          "graphql.type",
+		Tag:           "bytes,50001,opt,name=type",
+		Filename:      "graphql/graphql.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*GraphQLMessageOption)(nil),
+		Field:         50001,
+		Name:          "graphql.object",
+		Tag:           "bytes,50001,opt,name=object",
+		Filename:      "graphql/graphql.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*GraphQLFieldOption)(nil),
+		Field:         50001,
+		Name:          "graphql.field",
+		Tag:           "bytes,50001,opt,name=field",
+		Filename:      "graphql/graphql.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumOptions)(nil),
+		ExtensionType: (*GraphQLEnumOption)(nil),
+		Field:         50001,
+		Name:          "graphql.enum",
+		Tag:           "bytes,50001,opt,name=enum",
+		Filename:      "graphql/graphql.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
+		ExtensionType: (*GraphQLEnumValueOption)(nil),
+		Field:         50001,
+		Name:          "graphql.enum_value",
+		Tag:           "bytes,50001,opt,name=enum_value",
+		Filename:      "graphql/graphql.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional graphql.GraphQLOption type = 50001;
+	E_Type = &file_graphql_graphql_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// optional graphql.GraphQLMessageOption object = 50001;
+	E_Object = &file_graphql_graphql_proto_extTypes[1]
+)
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional graphql.GraphQLFieldOption field = 50001;
+	E_Field = &file_graphql_graphql_proto_extTypes[2]
+)
+
+// Extension fields to descriptorpb.EnumOptions.
+var (
+	// optional graphql.GraphQLEnumOption enum = 50001;
+	E_Enum = &file_graphql_graphql_proto_extTypes[3]
+)
+
+// Extension fields to descriptorpb.EnumValueOptions.
+var (
+	// optional graphql.GraphQLEnumValueOption enum_value = 50001;
+	E_EnumValue = &file_graphql_graphql_proto_extTypes[4]
+)
+
+var File_graphql_graphql_proto protoreflect.FileDescriptor
+
+var file_graphql_graphql_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x67, 0x72, 0x61, 0x70, 0x68, 0x71, 0x6c, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x71,
+	0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x67, 0x72, 0x61, 0x70, 0x68, 0x71, 0x6c,
+	0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xec, 0x01, 0x0a, 0x0d, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x4c, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x08,
+	0x6d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x08, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0c, 0x73, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x2d, 0x0a, 0x12, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x64, 0x65,
+	0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12,
+	0x21, 0x0a, 0x0c, 0x66, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x72, 0x67, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x66, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x41, 0x72,
+	0x67, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x22, 0xa0, 0x01, 0x0a, 0x14, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x4c, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x6b,
+	0x69, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x4f, 0x6e, 0x6c,
+	0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4f, 0x6e,
+	0x6c, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0xbf, 0x01, 0x0a, 0x12, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x4c,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x28, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x4c, 0x53,
+	0x6b, 0x69, 0x70, 0x52, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x64, 0x65, 0x70, 0x72, 0x65,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x5d, 0x0a, 0x11, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51,
+	0x4c, 0x45, 0x6e, 0x75, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73,
+	0x6b, 0x69, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x7d, 0x0a, 0x16, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x4c,
+	0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x2a, 0x4b, 0x0a, 0x0b, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x4c, 0x53,
+	0x6b, 0x69, 0x70, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x4b, 0x49, 0x50, 0x5f, 0x4e, 0x4f, 0x4e, 0x45,
+	0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x4b, 0x49, 0x50, 0x5f, 0x4f, 0x55, 0x54, 0x50, 0x55,
+	0x54, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x4b, 0x49, 0x50, 0x5f, 0x49, 0x4e, 0x50, 0x55,
+	0x54, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x4b, 0x49, 0x50, 0x5f, 0x41, 0x4c, 0x4c, 0x10,
+	0x03, 0x3a, 0x4c, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd1, 0x86, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70,
+	0x68, 0x51, 0x4c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x3a,
+	0x58, 0x0a, 0x06, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd1, 0x86, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61,
+	0x70, 0x68, 0x51, 0x4c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x06, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x3a, 0x52, 0x0a, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0xd1, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x4c, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x3a, 0x4e, 0x0a,
+	0x04, 0x65, 0x6e, 0x75, 0x6d, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0xd1, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x4c, 0x45, 0x6e, 0x75,
+	0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x65, 0x6e, 0x75, 0x6d, 0x3a, 0x63, 0x0a,
+	0x0a, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x21, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e,
+	0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd1,
+	0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x71, 0x6c,
+	0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x4c, 0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x65, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x68, 0x69, 0x63, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x71, 0x6c, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x65, 0x64, 0x67, 0x65, 0x2f, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x71, 0x6c,
+}
+
+var (
+	file_graphql_graphql_proto_rawDescOnce sync.Once
+	file_graphql_graphql_proto_rawDescData = file_graphql_graphql_proto_rawDesc
+)
+
+func file_graphql_graphql_proto_rawDescGZIP() []byte {
+	file_graphql_graphql_proto_rawDescOnce.Do(func() {
+		file_graphql_graphql_proto_rawDescData = protoimpl.X.CompressGZIP(file_graphql_graphql_proto_rawDescData)
+	})
+	return file_graphql_graphql_proto_rawDescData
+}
+
+var file_graphql_graphql_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_graphql_graphql_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_graphql_graphql_proto_goTypes = []interface{}{
+	(GraphQLSkip)(0),                      // 0: graphql.GraphQLSkip
+	(*GraphQLOption)(nil),                 // 1: graphql.GraphQLOption
+	(*GraphQLMessageOption)(nil),          // 2: graphql.GraphQLMessageOption
+	(*GraphQLFieldOption)(nil),            // 3: graphql.GraphQLFieldOption
+	(*GraphQLEnumOption)(nil),             // 4: graphql.GraphQLEnumOption
+	(*GraphQLEnumValueOption)(nil),        // 5: graphql.GraphQLEnumValueOption
+	(*descriptorpb.MethodOptions)(nil),    // 6: google.protobuf.MethodOptions
+	(*descriptorpb.MessageOptions)(nil),   // 7: google.protobuf.MessageOptions
+	(*descriptorpb.FieldOptions)(nil),     // 8: google.protobuf.FieldOptions
+	(*descriptorpb.EnumOptions)(nil),      // 9: google.protobuf.EnumOptions
+	(*descriptorpb.EnumValueOptions)(nil), // 10: google.protobuf.EnumValueOptions
+}
+var file_graphql_graphql_proto_depIdxs = []int32{
+	0,  // 0: graphql.GraphQLFieldOption.skip:type_name -> graphql.GraphQLSkip
+	6,  // 1: graphql.type:extendee -> google.protobuf.MethodOptions
+	7,  // 2: graphql.object:extendee -> google.protobuf.MessageOptions
+	8,  // 3: graphql.field:extendee -> google.protobuf.FieldOptions
+	9,  // 4: graphql.enum:extendee -> google.protobuf.EnumOptions
+	10, // 5: graphql.enum_value:extendee -> google.protobuf.EnumValueOptions
+	1,  // 6: graphql.type:type_name -> graphql.GraphQLOption
+	2,  // 7: graphql.object:type_name -> graphql.GraphQLMessageOption
+	3,  // 8: graphql.field:type_name -> graphql.GraphQLFieldOption
+	4,  // 9: graphql.enum:type_name -> graphql.GraphQLEnumOption
+	5,  // 10: graphql.enum_value:type_name -> graphql.GraphQLEnumValueOption
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	6,  // [6:11] is the sub-list for extension type_name
+	1,  // [1:6] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_graphql_graphql_proto_init() }
+func file_graphql_graphql_proto_init() {
+	if File_graphql_graphql_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_graphql_graphql_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphQLOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graphql_graphql_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphQLMessageOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graphql_graphql_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphQLFieldOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		x, err := b.DecodeStringBytes()
-		m.Type = &GraphQLOption_Mutation{x}
-		return true, err
-	default:
-		return false, nil
-	}
-}
-
-func _GraphQLOption_OneofSizer(msg proto.Message) (n int) {
-	m := msg.(*GraphQLOption)
-	// type
-	switch x := m.Type.(type) {
-	case *GraphQLOption_Query:
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(len(x.Query)))
-		n += len(x.Query)
-	case *GraphQLOption_Mutation:
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(len(x.Mutation)))
-		n += len(x.Mutation)
-	case nil:
-	default:
-		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
-	}
-	return n
-}
-
-var E_Type = &proto.ExtensionDesc{
-	ExtendedType:  (*descriptor.MethodOptions)(nil),
-	ExtensionType: (*GraphQLOption)(nil),
-	Field:         50001,
-	Name:          "graphql.type",
-	Tag:           "bytes,50001,opt,name=type",
-	Filename:      "graphql/graphql.proto",
-}
-
-func init() {
-	proto.RegisterType((*GraphQLOption)(nil), "graphql.GraphQLOption")
-	proto.RegisterExtension(E_Type)
-}
-
-func init() { proto.RegisterFile("graphql/graphql.proto", fileDescriptor_graphql_bf0c669ee24265f1) }
-
-var fileDescriptor_graphql_bf0c669ee24265f1 = []byte{
-	// 172 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4d, 0x2f, 0x4a, 0x2c,
-	0xc8, 0x28, 0xcc, 0xd1, 0x87, 0xd2, 0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0xec, 0x50, 0xae,
-	0x94, 0x42, 0x7a, 0x7e, 0x7e, 0x7a, 0x4e, 0xaa, 0x3e, 0x58, 0x38, 0xa9, 0x34, 0x4d, 0x3f, 0x25,
-	0xb5, 0x38, 0xb9, 0x28, 0xb3, 0xa0, 0x24, 0xbf, 0x08, 0xa2, 0x54, 0xc9, 0x97, 0x8b, 0xd7, 0x1d,
-	0xa4, 0x38, 0xd0, 0xc7, 0xbf, 0xa0, 0x24, 0x33, 0x3f, 0x4f, 0x48, 0x8c, 0x8b, 0xb5, 0xb0, 0x34,
-	0xb5, 0xa8, 0x52, 0x82, 0x51, 0x81, 0x51, 0x83, 0xd3, 0x83, 0x21, 0x08, 0xc2, 0x15, 0x92, 0xe1,
-	0xe2, 0xc8, 0x2d, 0x2d, 0x49, 0x04, 0xa9, 0x91, 0x60, 0x82, 0x4a, 0xc1, 0x45, 0x9c, 0xd8, 0xb8,
-	0x58, 0x4a, 0x2a, 0x0b, 0x52, 0xad, 0x7c, 0x20, 0xb4, 0x90, 0x9c, 0x1e, 0xc4, 0x66, 0x3d, 0x98,
-	0xcd, 0x7a, 0xbe, 0xa9, 0x25, 0x19, 0xf9, 0x29, 0x10, 0x4b, 0x8a, 0x25, 0x2e, 0xb6, 0x31, 0x2b,
-	0x30, 0x6a, 0x70, 0x1b, 0x89, 0xe9, 0xc1, 0x5c, 0x8e, 0xe2, 0x8a, 0x20, 0xb0, 0x29, 0x80, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xa0, 0x79, 0xde, 0xcf, 0xdf, 0x00, 0x00, 0x00,
+		file_graphql_graphql_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphQLEnumOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graphql_graphql_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphQLEnumValueOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_graphql_graphql_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*GraphQLOption_Query)(nil),
+		(*GraphQLOption_Mutation)(nil),
+		(*GraphQLOption_Subscription)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_graphql_graphql_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 5,
+			NumServices:   0,
+		},
+		GoTypes:           file_graphql_graphql_proto_goTypes,
+		DependencyIndexes: file_graphql_graphql_proto_depIdxs,
+		EnumInfos:         file_graphql_graphql_proto_enumTypes,
+		MessageInfos:      file_graphql_graphql_proto_msgTypes,
+		ExtensionInfos:    file_graphql_graphql_proto_extTypes,
+	}.Build()
+	File_graphql_graphql_proto = out.File
+	file_graphql_graphql_proto_rawDesc = nil
+	file_graphql_graphql_proto_goTypes = nil
+	file_graphql_graphql_proto_depIdxs = nil
 }
@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/graphql-go/graphql"
+)
+
+var ErrOneOfInput error = fmt.Errorf("exactly one field of a oneof input must be set")
+
+// RenameInputField declares that the field name of the input type is decoded
+// from the protobuf field jsonName, for fields whose graphql name differs
+// from their protobuf JSON name
+func (r *Registry) RenameInputField(t *InputObject, name, jsonName string) {
+	if _, ok := r.inputFields[t]; !ok {
+		r.inputFields[t] = make(map[string]string)
+	}
+	r.inputFields[t][name] = jsonName
+}
+
+// OneOfInput declares that the input type holds the members of a protobuf
+// oneof: exactly one of its fields must be set, and the field is decoded into
+// the message enclosing the oneof
+func (r *Registry) OneOfInput(t *InputObject) {
+	r.oneOfInputs[t] = struct{}{}
+}
+
+func (r *Registry) isOneOfInput(t Input) bool {
+	for {
+		nonNull, ok := t.(*NonNull)
+		if !ok {
+			break
+		}
+		t = nonNull.OfType
+	}
+	obj, ok := t.(*InputObject)
+	if !ok {
+		return false
+	}
+	_, ok = r.oneOfInputs[obj]
+	return ok
+}
+
+// MarshalInput encodes an argument value of type t into protobuf JSON,
+// restoring the protobuf names of renamed input fields. ErrOneOfInput is
+// returned when a oneof input does not have exactly one field set
+func (r *Registry) MarshalInput(t Input, value interface{}) ([]byte, error) {
+	res, err := r.protoInput(t, value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(res)
+}
+
+func (r *Registry) protoInput(t Input, value interface{}) (interface{}, error) {
+	switch t := t.(type) {
+	case *NonNull:
+		return r.protoInput(t.OfType, value)
+	case *List:
+		values, ok := value.([]interface{})
+		if !ok {
+			return value, nil
+		}
+		res := make([]interface{}, len(values))
+		for i, v := range values {
+			elem, err := r.protoInput(t.OfType, v)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = elem
+		}
+		return res, nil
+	case *InputObject:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return value, nil
+		}
+		names := r.inputFields[t]
+		_, isOneOf := r.oneOfInputs[t]
+		defs := t.Fields()
+		res := make(map[string]interface{}, len(fields))
+		set := 0
+		for name, v := range fields {
+			if v != nil {
+				set++
+			} else if isOneOf {
+				// an explicit null member is left unset
+				continue
+			}
+			def, ok := defs[name]
+			if ok {
+				var err error
+				if v, err = r.protoInput(def.Type, v); err != nil {
+					return nil, err
+				}
+			}
+			if members, isMap := v.(map[string]interface{}); ok && isMap && r.isOneOfInput(def.Type) {
+				// the members of a oneof are fields of the enclosing message
+				for member, mv := range members {
+					res[member] = mv
+				}
+				continue
+			}
+			if jsonName, ok := names[name]; ok {
+				name = jsonName
+			}
+			res[name] = v
+		}
+		if isOneOf && set != 1 {
+			return nil, fmt.Errorf("%s: %w, got %d", t.Name(), ErrOneOfInput, set)
+		}
+		return res, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,155 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	. "github.com/graphql-go/graphql"
+)
+
+func TestMarshalInput(t *testing.T) {
+	reg := NewRegistry()
+	item := NewInputObject(InputObjectConfig{
+		Name: "MarshalInputItem",
+		Fields: InputObjectConfigFieldMap{
+			"label": &InputObjectFieldConfig{Type: String},
+		},
+	})
+	reg.RenameInputField(item, "label", "displayName")
+	input := NewInputObject(InputObjectConfig{
+		Name: "MarshalInput",
+		Fields: InputObjectConfigFieldMap{
+			"newName": &InputObjectFieldConfig{Type: String},
+			"id":      &InputObjectFieldConfig{Type: NewNonNull(String)},
+			"items":   &InputObjectFieldConfig{Type: NewList(NewNonNull(item))},
+		},
+	})
+	reg.RenameInputField(input, "newName", "oldName")
+	value := map[string]interface{}{
+		"id":      "1",
+		"newName": "edge",
+		"items": []interface{}{
+			map[string]interface{}{"label": "a"},
+			map[string]interface{}{"label": "b"},
+		},
+	}
+	b, err := reg.MarshalInput(NewNonNull(input), value)
+	if err != nil {
+		t.Fatalf("failed to marshal input: %s", err.Error())
+	}
+	res := make(map[string]interface{})
+	if err := json.Unmarshal(b, &res); err != nil {
+		t.Fatalf("failed to unmarshal input: %s", err.Error())
+	}
+	want := map[string]interface{}{
+		"id":      "1",
+		"oldName": "edge",
+		"items": []interface{}{
+			map[string]interface{}{"displayName": "a"},
+			map[string]interface{}{"displayName": "b"},
+		},
+	}
+	if diff := cmp.Diff(want, res); diff != "" {
+		t.Error(diff)
+	}
+
+	// the renames of a registry do not apply to the other registries
+	b, err = NewRegistry().MarshalInput(input, map[string]interface{}{"newName": "edge"})
+	if err != nil {
+		t.Fatalf("failed to marshal input: %s", err.Error())
+	}
+	if string(b) != `{"newName":"edge"}` {
+		t.Errorf("unexpected input of another registry: %s", b)
+	}
+}
+
+func TestMarshalOneOfInput(t *testing.T) {
+	reg := NewRegistry()
+	member := NewInputObject(InputObjectConfig{
+		Name: "MarshalOneOfMember",
+		Fields: InputObjectConfigFieldMap{
+			"msg": &InputObjectFieldConfig{Type: String},
+		},
+	})
+	oneOf := NewInputObject(InputObjectConfig{
+		Name: "MarshalOneOfKind",
+		Fields: InputObjectConfigFieldMap{
+			"client":  &InputObjectFieldConfig{Type: member},
+			"newCode": &InputObjectFieldConfig{Type: Int},
+		},
+	})
+	reg.OneOfInput(oneOf)
+	reg.RenameInputField(oneOf, "newCode", "code")
+	input := NewInputObject(InputObjectConfig{
+		Name: "MarshalOneOf",
+		Fields: InputObjectConfigFieldMap{
+			"id":   &InputObjectFieldConfig{Type: String},
+			"kind": &InputObjectFieldConfig{Type: oneOf},
+		},
+	})
+	cases := []struct {
+		name  string
+		value map[string]interface{}
+		want  map[string]interface{}
+		err   bool
+	}{
+		{
+			name:  "message member",
+			value: map[string]interface{}{"id": "1", "kind": map[string]interface{}{"client": map[string]interface{}{"msg": "a"}}},
+			want:  map[string]interface{}{"id": "1", "client": map[string]interface{}{"msg": "a"}},
+		},
+		{
+			name:  "renamed member",
+			value: map[string]interface{}{"kind": map[string]interface{}{"newCode": 2}},
+			want:  map[string]interface{}{"code": float64(2)},
+		},
+		{
+			name:  "oneof not set",
+			value: map[string]interface{}{"id": "1"},
+			want:  map[string]interface{}{"id": "1"},
+		},
+		{
+			name:  "no member",
+			value: map[string]interface{}{"kind": map[string]interface{}{}},
+			err:   true,
+		},
+		{
+			name:  "null member",
+			value: map[string]interface{}{"kind": map[string]interface{}{"newCode": nil}},
+			err:   true,
+		},
+		{
+			name:  "null and set members",
+			value: map[string]interface{}{"kind": map[string]interface{}{"newCode": nil, "client": map[string]interface{}{"msg": "a"}}},
+			want:  map[string]interface{}{"client": map[string]interface{}{"msg": "a"}},
+		},
+		{
+			name:  "several members",
+			value: map[string]interface{}{"kind": map[string]interface{}{"newCode": 2, "client": map[string]interface{}{}}},
+			err:   true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b, err := reg.MarshalInput(input, c.value)
+			if c.err {
+				if !errors.Is(err, ErrOneOfInput) {
+					t.Fatalf("expected %v, got %s, %v", ErrOneOfInput, b, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to marshal input: %s", err.Error())
+			}
+			res := make(map[string]interface{})
+			if err := json.Unmarshal(b, &res); err != nil {
+				t.Fatalf("failed to unmarshal input: %s", err.Error())
+			}
+			if diff := cmp.Diff(c.want, res); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package graphql
+
+import (
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataForwarder copies the allowed headers and cookies of HTTP requests
+// into the outgoing gRPC metadata of the upstream rpc(s)
+type MetadataForwarder struct {
+	// Headers is the allow-list of forwarded header names
+	Headers []string
+	// Cookies is the allow-list of forwarded cookie names
+	Cookies []string
+	// Rename maps an entry of Headers or Cookies to its metadata key, the
+	// lowercased name is used otherwise
+	Rename map[string]string
+	// Hook derives additional metadata from the request
+	Hook func(r *http.Request) metadata.MD
+}
+
+// Metadata returns the metadata forwarded for the request
+func (f *MetadataForwarder) Metadata(r *http.Request) metadata.MD {
+	md := metadata.MD{}
+	for _, name := range f.Headers {
+		if values := r.Header[http.CanonicalHeaderKey(name)]; len(values) > 0 {
+			md.Append(f.key(name), values...)
+		}
+	}
+	for _, name := range f.Cookies {
+		if c, err := r.Cookie(name); err == nil {
+			md.Append(f.key(name), c.Value)
+		}
+	}
+	if f.Hook != nil {
+		md = metadata.Join(md, f.Hook(r))
+	}
+	return md
+}
+
+func (f *MetadataForwarder) key(name string) string {
+	if key, ok := f.Rename[name]; ok {
+		return strings.ToLower(key)
+	}
+	return strings.ToLower(name)
+}
+
+// Handler wraps h so the resolvers of the request call the upstream rpc(s)
+// with the forwarded metadata, merged with the outgoing metadata of the
+// request context
+func (f *MetadataForwarder) Handler(h http.Handler) http.Handler {
+	if f == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		md := f.Metadata(r)
+		if md.Len() == 0 {
+			h.ServeHTTP(w, r)
+			return
+		}
+		ctx := r.Context()
+		if outgoing, ok := metadata.FromOutgoingContext(ctx); ok {
+			md = metadata.Join(outgoing, md)
+		}
+		h.ServeHTTP(w, r.WithContext(metadata.NewOutgoingContext(ctx, md)))
+	})
+}
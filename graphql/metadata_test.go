@@ -0,0 +1,47 @@
+package graphql
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestMetadataForwarder(t *testing.T) {
+	f := &MetadataForwarder{
+		Headers: []string{"Authorization", "accept-language", "X-Missing"},
+		Cookies: []string{"session"},
+		Rename:  map[string]string{"accept-language": "locale", "session": "x-session"},
+		Hook: func(r *http.Request) metadata.MD {
+			return metadata.Pairs("x-forwarded-for", r.RemoteAddr)
+		},
+	}
+	var got metadata.MD
+	h := f.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = metadata.FromOutgoingContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Add("Accept-Language", "id-ID")
+	req.Header.Add("Accept-Language", "en-US")
+	req.Header.Set("X-Ignored", "ignored")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+	req.AddCookie(&http.Cookie{Name: "tracking", Value: "ignored"})
+	req = req.WithContext(metadata.AppendToOutgoingContext(req.Context(), "x-request-id", "42"))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	want := metadata.MD{
+		"x-request-id":    {"42"},
+		"authorization":   {"Bearer token"},
+		"locale":          {"id-ID", "en-US"},
+		"x-session":       {"s3cr3t"},
+		"x-forwarded-for": {"10.0.0.1:1234"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
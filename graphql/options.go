@@ -0,0 +1,293 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	. "github.com/graphql-go/graphql"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The graphql options of the proto declarations and the rules derived from
+// them, shared by the generator and the dynamic schema so both build the same
+// types.
+
+var (
+	ErrNameCollision error = errors.New("graphql type name collision")
+	ErrInvalidName         = errors.New("invalid graphql type name")
+	ErrResponseField       = errors.New("invalid graphql response_field")
+)
+
+var (
+	namePattern   = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+	reservedNames = map[string]struct{}{
+		"String": {}, "Int": {}, "Float": {}, "Boolean": {}, "ID": {},
+		"RootQuery": {}, "RootMutation": {}, "RootSubscription": {},
+	}
+)
+
+func MessageOption(d protoreflect.MessageDescriptor) *GraphQLMessageOption {
+	opt, ok := proto.GetExtension(d.Options(), E_Object).(*GraphQLMessageOption)
+	if !ok || opt == nil {
+		return &GraphQLMessageOption{}
+	}
+	return opt
+}
+
+func EnumOption(d protoreflect.EnumDescriptor) *GraphQLEnumOption {
+	opt, ok := proto.GetExtension(d.Options(), E_Enum).(*GraphQLEnumOption)
+	if !ok || opt == nil {
+		return &GraphQLEnumOption{}
+	}
+	return opt
+}
+
+func EnumValueOption(d protoreflect.EnumValueDescriptor) *GraphQLEnumValueOption {
+	opt, ok := proto.GetExtension(d.Options(), E_EnumValue).(*GraphQLEnumValueOption)
+	if !ok || opt == nil {
+		return &GraphQLEnumValueOption{}
+	}
+	return opt
+}
+
+func FieldOption(d protoreflect.FieldDescriptor) *GraphQLFieldOption {
+	opt, ok := proto.GetExtension(d.Options(), E_Field).(*GraphQLFieldOption)
+	if !ok || opt == nil {
+		return &GraphQLFieldOption{}
+	}
+	return opt
+}
+
+func MethodOption(d protoreflect.MethodDescriptor) *GraphQLOption {
+	opt, ok := proto.GetExtension(d.Options(), E_Type).(*GraphQLOption)
+	if !ok || opt == nil {
+		return &GraphQLOption{}
+	}
+	return opt
+}
+
+// The operation types of the rpc(s)
+const (
+	OperationQuery        = "Query"
+	OperationMutation     = "Mutation"
+	OperationSubscription = "Subscription"
+)
+
+// Operation returns the type and the name of the graphql operation of an rpc.
+// The name is empty for the rpc(s) without operation, which are skipped
+func Operation(md protoreflect.MethodDescriptor) (string, string, error) {
+	opt := MethodOption(md)
+	unary := !md.IsStreamingClient() && !md.IsStreamingServer()
+	switch {
+	case opt.GetType() == nil:
+		return "", "", nil
+	case opt.GetQuery() != "" && unary:
+		return OperationQuery, opt.GetQuery(), nil
+	case opt.GetMutation() != "" && unary:
+		return OperationMutation, opt.GetMutation(), nil
+	case opt.GetSubscription() != "" && md.IsStreamingServer() && !md.IsStreamingClient():
+		return OperationSubscription, opt.GetSubscription(), nil
+	}
+	return "", "", fmt.Errorf("%w for method: %s: queries and mutations must be unary and subscriptions server streaming", ErrInvalidOperation, md.FullName())
+}
+
+// NameOption returns the graphql name given by the option of a message or an
+// enum, or an empty string
+func NameOption(d protoreflect.Descriptor) string {
+	switch d := d.(type) {
+	case protoreflect.MessageDescriptor:
+		return MessageOption(d).GetName()
+	case protoreflect.EnumDescriptor:
+		return EnumOption(d).GetName()
+	}
+	return ""
+}
+
+// BaseName returns the PascalCase name of a declaration within its package,
+// qualified with the PascalCase package when withPackage is set
+func BaseName(d protoreflect.Descriptor, withPackage bool) string {
+	pkg := string(d.ParentFile().Package())
+	name := strings.TrimPrefix(string(d.FullName()), pkg+".")
+	base := ""
+	for _, part := range strings.Split(name, ".") {
+		base += PascalCase(part)
+	}
+	if withPackage && pkg != "" {
+		prefix := ""
+		for _, part := range strings.Split(pkg, ".") {
+			prefix += PascalCase(part)
+		}
+		base = prefix + base
+	}
+	return base
+}
+
+// PascalCase converts snake_case and camelCase names to PascalCase
+func PascalCase(s string) string {
+	res := ""
+	for _, part := range strings.Split(s, "_") {
+		if part == "" {
+			continue
+		}
+		res += strings.ToUpper(part[:1]) + part[1:]
+	}
+	return res
+}
+
+// IsValidName reports whether name is a valid graphql type name
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// IsReservedName reports whether name is a built-in scalar or a root type
+func IsReservedName(name string) bool {
+	_, ok := reservedNames[name]
+	return ok
+}
+
+// FieldName returns the graphql name of the field
+func FieldName(d protoreflect.FieldDescriptor) string {
+	if name := FieldOption(d).GetName(); name != "" {
+		return name
+	}
+	return d.JSONName()
+}
+
+// EnumValueName returns the graphql name of the enum value
+func EnumValueName(d protoreflect.EnumValueDescriptor) string {
+	if name := EnumValueOption(d).GetName(); name != "" {
+		return name
+	}
+	return string(d.Name())
+}
+
+// IsHidden reports whether no input type, or no object type, is built for
+// the message
+func IsHidden(d protoreflect.MessageDescriptor, input bool) bool {
+	opt := MessageOption(d)
+	switch {
+	case opt.GetSkip():
+		return true
+	case input:
+		return opt.GetOutputOnly()
+	}
+	return opt.GetInputOnly()
+}
+
+// IsSkipped reports whether the field is hidden from the input type, or from
+// the object type, either by its own option or by the option of its message
+// or enum
+func IsSkipped(d protoreflect.FieldDescriptor, input bool) bool {
+	if d.Message() != nil && !d.IsMap() && IsHidden(d.Message(), input) {
+		return true
+	}
+	if d.Enum() != nil && EnumOption(d.Enum()).GetSkip() {
+		return true
+	}
+	switch FieldOption(d).GetSkip() {
+	case GraphQLSkip_SKIP_ALL:
+		return true
+	case GraphQLSkip_SKIP_OUTPUT:
+		return !input
+	case GraphQLSkip_SKIP_INPUT:
+		return input
+	}
+	return false
+}
+
+// IsOneofMember reports whether the field belongs to a oneof which is not
+// the synthetic oneof of a proto3 optional field
+func IsOneofMember(d protoreflect.FieldDescriptor) bool {
+	o := d.ContainingOneof()
+	return o != nil && !o.IsSynthetic()
+}
+
+// IsWrappedMember reports whether the oneof member is held by a wrapper
+// object type in the union of the oneof, which is the case of every member
+// whose graphql type is not an object
+func IsWrappedMember(d protoreflect.FieldDescriptor) bool {
+	if d.Message() == nil {
+		return true
+	}
+	t, ok := WellKnownOutput(d.Message().FullName())
+	if !ok {
+		return false
+	}
+	_, isObject := t.(*Object)
+	return !isObject
+}
+
+// ResponseFields returns the fields along the response_field path of an rpc
+// output, the operation returns the value of the last one. No field is
+// returned for an empty path
+func ResponseFields(md protoreflect.MessageDescriptor, path string) ([]protoreflect.FieldDescriptor, error) {
+	res := make([]protoreflect.FieldDescriptor, 0)
+	if path == "" {
+		return res, nil
+	}
+	parts := strings.Split(path, ".")
+	for i, name := range parts {
+		field := md.Fields().ByName(protoreflect.Name(name))
+		last := i == len(parts)-1
+		switch {
+		case field == nil:
+			return nil, fmt.Errorf("%w %q: %s has no field %q", ErrResponseField, path, md.FullName(), name)
+		case field.IsMap():
+			return nil, fmt.Errorf("%w %q: map field %s is not supported", ErrResponseField, path, field.FullName())
+		case field.IsList() && field.Enum() != nil:
+			return nil, fmt.Errorf("%w %q: repeated enum field %s is not supported", ErrResponseField, path, field.FullName())
+		case !last && (field.Message() == nil || field.IsList()):
+			return nil, fmt.Errorf("%w %q: %s is not a singular message field", ErrResponseField, path, field.FullName())
+		}
+		res = append(res, field)
+		md = field.Message()
+	}
+	return res, nil
+}
+
+// Description returns the graphql description of a declaration: the
+// description given by its graphql option, or else its leading proto comment
+func Description(option string, d protoreflect.Descriptor) string {
+	if option != "" {
+		return option
+	}
+	loc := d.ParentFile().SourceLocations().ByDescriptor(d)
+	return commentText(loc.LeadingComments)
+}
+
+// TypeDescription returns the description of a message, an enum or a oneof
+func TypeDescription(d protoreflect.Descriptor) string {
+	option := ""
+	switch d := d.(type) {
+	case protoreflect.MessageDescriptor:
+		option = MessageOption(d).GetDescription()
+	case protoreflect.EnumDescriptor:
+		option = EnumOption(d).GetDescription()
+	}
+	return Description(option, d)
+}
+
+// Deprecation returns the graphql deprecation reason of a declaration: the
+// reason given by its graphql option, or else the default reason when the
+// declaration has the proto deprecated option
+func Deprecation(option string, d protoreflect.Descriptor) string {
+	if option != "" {
+		return option
+	}
+	if opts, ok := d.Options().(interface{ GetDeprecated() bool }); ok && opts.GetDeprecated() {
+		return DefaultDeprecationReason
+	}
+	return ""
+}
+
+// commentText strips the comment indentation and surrounding blank lines
+func commentText(comment string) string {
+	lines := strings.Split(strings.TrimSpace(comment), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,154 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/graphql-go/graphql"
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// RegisterReflection registers the graphql operations of every service
+// exposed by the gRPC server reflection service of conn
+func (r *Registry) RegisterReflection(ctx context.Context, conn grpc.ClientConnInterface) error {
+	files, services, err := fetchReflection(ctx, conn)
+	if err != nil {
+		return err
+	}
+	for _, name := range services {
+		d, err := files.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			continue
+		}
+		sd, ok := d.(protoreflect.ServiceDescriptor)
+		if !ok {
+			continue
+		}
+		if err := r.RegisterService(sd, conn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewSchemaFromReflection builds a schema from the services exposed by the
+// gRPC server reflection service of conn, without generated code
+func NewSchemaFromReflection(ctx context.Context, conn grpc.ClientConnInterface) (*Schema, error) {
+	reg := NewRegistry()
+	if err := reg.RegisterReflection(ctx, conn); err != nil {
+		return nil, err
+	}
+	return reg.Schema()
+}
+
+type reflectionClient struct {
+	stream rpb.ServerReflection_ServerReflectionInfoClient
+	files  map[string]*descriptorpb.FileDescriptorProto
+}
+
+func fetchReflection(ctx context.Context, conn grpc.ClientConnInterface) (*protoregistry.Files, []string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	c := &reflectionClient{stream, make(map[string]*descriptorpb.FileDescriptorProto)}
+	res, err := c.send(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{ListServices: "*"},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	services := make([]string, 0)
+	for _, svc := range res.GetListServicesResponse().GetService() {
+		services = append(services, svc.GetName())
+		res, err := c.send(&rpb.ServerReflectionRequest{
+			MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: svc.GetName()},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := c.addFiles(res); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := c.resolveDependencies(); err != nil {
+		return nil, nil, err
+	}
+	fds := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range c.files {
+		if fd != nil {
+			fds.File = append(fds.File, fd)
+		}
+	}
+	files, err := newFiles(fds)
+	if err != nil {
+		return nil, nil, err
+	}
+	return files, services, nil
+}
+
+func (c *reflectionClient) send(req *rpb.ServerReflectionRequest) (*rpb.ServerReflectionResponse, error) {
+	if err := c.stream.Send(req); err != nil {
+		return nil, err
+	}
+	return c.stream.Recv()
+}
+
+func (c *reflectionClient) addFiles(res *rpb.ServerReflectionResponse) error {
+	if e := res.GetErrorResponse(); e != nil {
+		return fmt.Errorf("server reflection error: %s", e.GetErrorMessage())
+	}
+	for _, b := range res.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		fd := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(b, fd); err != nil {
+			return err
+		}
+		c.files[fd.GetName()] = fd
+	}
+	return nil
+}
+
+// resolveDependencies fetches the dependencies that were not sent along with
+// the requested files. Dependencies the server does not know about are left
+// for newFiles to resolve.
+func (c *reflectionClient) resolveDependencies() error {
+	for {
+		missing := make([]string, 0)
+		for _, fd := range c.files {
+			for _, dep := range fd.GetDependency() {
+				if _, ok := c.files[dep]; !ok {
+					missing = append(missing, dep)
+				}
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		for _, dep := range missing {
+			if _, ok := c.files[dep]; ok {
+				continue
+			}
+			res, err := c.send(&rpb.ServerReflectionRequest{
+				MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+			})
+			if err != nil {
+				return err
+			}
+			if res.GetErrorResponse() == nil {
+				if err := c.addFiles(res); err != nil {
+					return err
+				}
+			}
+			if _, ok := c.files[dep]; !ok {
+				c.files[dep] = nil
+			}
+		}
+	}
+}
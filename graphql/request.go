@@ -0,0 +1,55 @@
+package graphql
+
+import (
+	"context"
+
+	. "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// RequestOptions is a graphql operation request sent by transport clients
+type RequestOptions struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+	Extensions    map[string]interface{} `json:"extensions"`
+}
+
+func isSubscription(opts RequestOptions) bool {
+	doc, err := parser.Parse(parser.ParseParams{Source: opts.Query})
+	if err != nil {
+		return false
+	}
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if opts.OperationName != "" && (op.Name == nil || op.Name.Value != opts.OperationName) {
+			continue
+		}
+		return op.Operation == ast.OperationTypeSubscription
+	}
+	return false
+}
+
+// execute runs the requested operation against the schema. Subscription
+// operations produce a result for every event until ctx is done, other
+// operations produce exactly one result.
+func execute(ctx context.Context, schema *Schema, opts RequestOptions) chan *Result {
+	params := Params{
+		Schema:         *schema,
+		RequestString:  opts.Query,
+		VariableValues: opts.Variables,
+		OperationName:  opts.OperationName,
+		Context:        ctx,
+	}
+	if isSubscription(opts) {
+		return Subscribe(params)
+	}
+	ch := make(chan *Result, 1)
+	ch <- Do(params)
+	close(ch)
+	return ch
+}
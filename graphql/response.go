@@ -0,0 +1,176 @@
+package graphql
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	ErrHijackUnsupported error = errors.New("hijack unsupported")
+)
+
+// MergePolicy sets how the values of a metadata key returned by several
+// rpc(s) of one request are merged, in calling order. The fields of mutations
+// are resolved in document order, but the fields of queries in no particular
+// order
+type MergePolicy int
+
+const (
+	// MergeAppend keeps the values of every rpc
+	MergeAppend MergePolicy = iota
+	// MergeFirst keeps the values of the first rpc returning the key
+	MergeFirst
+	// MergeLast keeps the values of the last rpc returning the key
+	MergeLast
+)
+
+type responseCollectorKey struct{}
+
+type responseCall struct {
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+// responseCollector records the response metadata of the rpc(s) called while
+// resolving a request, in calling order
+type responseCollector struct {
+	mu    sync.Mutex
+	calls []*responseCall
+}
+
+// CallOptions returns the options capturing the response headers and
+// trailers of an rpc into the collector of ctx, nil when ctx has none
+func CallOptions(ctx context.Context) []grpc.CallOption {
+	c, ok := ctx.Value(responseCollectorKey{}).(*responseCollector)
+	if !ok {
+		return nil
+	}
+	call := &responseCall{}
+	c.mu.Lock()
+	c.calls = append(c.calls, call)
+	c.mu.Unlock()
+	return []grpc.CallOption{grpc.Header(&call.header), grpc.Trailer(&call.trailer)}
+}
+
+// ResponseForwarder copies the allowed keys of the response headers and
+// trailers of the upstream rpc(s) into the HTTP response headers
+type ResponseForwarder struct {
+	// Keys is the allow-list of forwarded metadata keys
+	Keys []string
+	// Rename maps an entry of Keys to its HTTP header name, the canonical
+	// form of the key is used otherwise
+	Rename map[string]string
+	// Policies maps an entry of Keys to its MergePolicy, MergeAppend by
+	// default. The values of MergeFirst and MergeLast keys do not replace a
+	// header already set by the wrapped handler
+	Policies map[string]MergePolicy
+}
+
+// Handler wraps h so the response metadata of the rpc(s) called by the
+// request are collected, then merged into the HTTP response headers when they
+// are written. The headers of subscriptions being written before any rpc is
+// called, only queries and mutations are forwarded
+func (f *ResponseForwarder) Handler(h http.Handler) http.Handler {
+	if f == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := &responseCollector{}
+		ctx := context.WithValue(r.Context(), responseCollectorKey{}, c)
+		rw := &responseWriter{
+			ResponseWriter: w,
+			forward: func() {
+				f.merge(w.Header(), c)
+			},
+		}
+		h.ServeHTTP(rw, r.WithContext(ctx))
+	})
+}
+
+func (f *ResponseForwarder) merge(header http.Header, c *responseCollector) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range f.Keys {
+		policy := f.Policies[key]
+		var values []string
+		for _, call := range c.calls {
+			v := append(append([]string{}, call.header.Get(key)...), call.trailer.Get(key)...)
+			if len(v) == 0 {
+				continue
+			}
+			switch policy {
+			case MergeFirst:
+				if values == nil {
+					values = v
+				}
+			case MergeLast:
+				values = v
+			default:
+				values = append(values, v...)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		name, ok := f.Rename[key]
+		if !ok {
+			name = key
+		}
+		name = http.CanonicalHeaderKey(name)
+		switch _, set := header[name]; {
+		case policy == MergeAppend:
+			values = append(header[name], values...)
+		case set:
+			// the header set by the wrapped handler is kept
+			continue
+		}
+		header[name] = values
+	}
+}
+
+// responseWriter forwards the collected response metadata before the HTTP
+// response headers are written
+type responseWriter struct {
+	http.ResponseWriter
+	forward     func()
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.forward()
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, ErrHijackUnsupported
+	}
+	return h.Hijack()
+}
@@ -0,0 +1,180 @@
+package graphql
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	. "github.com/graphql-go/graphql"
+	"github.com/graphql-go/handler"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestResponseForwarder(t *testing.T) {
+	reg := NewRegistry()
+	call := func(header, trailer metadata.MD) *Field {
+		return &Field{
+			Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				for _, opt := range CallOptions(p.Context) {
+					switch o := opt.(type) {
+					case grpc.HeaderCallOption:
+						*o.HeaderAddr = header
+					case grpc.TrailerCallOption:
+						*o.TrailerAddr = trailer
+					}
+				}
+				return "ok", nil
+			},
+		}
+	}
+	// mutations are resolved in document order, unlike the fields of queries
+	reg.RegisterQuery("hello", &Field{Type: String})
+	reg.RegisterMutation("first", call(
+		metadata.Pairs("set-cookie", "a=1", "cache-control", "max-age=60"),
+		metadata.Pairs("x-trace", "first"),
+	))
+	reg.RegisterMutation("second", call(
+		metadata.Pairs("set-cookie", "b=2", "cache-control", "no-store", "x-internal", "secret"),
+		metadata.Pairs("x-trace", "second"),
+	))
+	schema, err := reg.Schema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &ResponseForwarder{
+		Keys:     []string{"set-cookie", "cache-control", "x-trace"},
+		Rename:   map[string]string{"x-trace": "x-upstream-trace"},
+		Policies: map[string]MergePolicy{"cache-control": MergeFirst, "x-trace": MergeLast},
+	}
+	h := f.Handler(handler.New(&handler.Config{Schema: schema}))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "mutation { first second }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	want := map[string][]string{
+		"Set-Cookie":       {"a=1", "b=2"},
+		"Cache-Control":    {"max-age=60"},
+		"X-Upstream-Trace": {"second"},
+		"X-Internal":       nil,
+	}
+	for name, values := range want {
+		if diff := cmp.Diff(values, rec.Header()[name]); diff != "" {
+			t.Errorf("%s: %s", name, diff)
+		}
+	}
+	if CallOptions(req.Context()) != nil {
+		t.Error("expected no call options without a collector")
+	}
+}
+
+func TestResponseForwarderHandlerHeader(t *testing.T) {
+	reg := NewRegistry()
+	reg.RegisterQuery("hello", &Field{
+		Type: String,
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			for _, opt := range CallOptions(p.Context) {
+				if o, ok := opt.(grpc.HeaderCallOption); ok {
+					*o.HeaderAddr = metadata.Pairs("cache-control", "no-store", "set-cookie", "b=2")
+				}
+			}
+			return "ok", nil
+		},
+	})
+	schema, err := reg.Schema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &ResponseForwarder{
+		Keys:     []string{"cache-control", "set-cookie"},
+		Policies: map[string]MergePolicy{"cache-control": MergeLast},
+	}
+	gql := handler.New(&handler.Config{Schema: schema})
+	h := f.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "private")
+		w.Header().Set("Set-Cookie", "a=1")
+		gql.ServeHTTP(w, r)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{ hello }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	want := map[string][]string{
+		"Cache-Control": {"private"},
+		"Set-Cookie":    {"a=1", "b=2"},
+	}
+	for name, values := range want {
+		if diff := cmp.Diff(values, rec.Header()[name]); diff != "" {
+			t.Errorf("%s: %s", name, diff)
+		}
+	}
+}
+
+func TestResponseForwarderSubscription(t *testing.T) {
+	reg := NewRegistry()
+	reg.RegisterQuery("hello", &Field{Type: String})
+	reg.RegisterSubscription("session", &Field{
+		Type: String,
+		Subscribe: func(p ResolveParams) (interface{}, error) {
+			// the headers of subscriptions are written before the stream
+			// returns any metadata
+			for _, opt := range CallOptions(p.Context) {
+				if o, ok := opt.(grpc.HeaderCallOption); ok {
+					*o.HeaderAddr = metadata.Pairs("set-cookie", "a=1")
+				}
+			}
+			ch := make(chan interface{}, 1)
+			ch <- "started"
+			close(ch)
+			return ch, nil
+		},
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source, nil
+		},
+	})
+	schema, err := reg.Schema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &ResponseForwarder{Keys: []string{"set-cookie"}}
+	query := "subscription { session }"
+
+	srv := httptest.NewServer(f.Handler(NewSSEHandler(schema)))
+	defer srv.Close()
+	res, err := http.Get(srv.URL + "?query=" + url.QueryEscape(query))
+	if err != nil {
+		t.Fatalf("request failed: %s", err.Error())
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %s", err.Error())
+	}
+	want := "event: next\ndata: {\"data\":{\"session\":\"started\"}}\n\nevent: complete\ndata:\n\n"
+	if string(body) != want {
+		t.Errorf("expected %q, got %q", want, string(body))
+	}
+	if cookies := res.Header["Set-Cookie"]; len(cookies) > 0 {
+		t.Errorf("unexpected forwarded header: %v", cookies)
+	}
+
+	conn := dialTestWebsocket(t, f.Handler(NewWebsocketHandler(schema)))
+	conn.WriteJSON(wsMessage{Type: wsConnectionInit})
+	if msg := readTestMessage(t, conn); msg.Type != wsConnectionAck {
+		t.Fatalf("expected %s, got %s", wsConnectionAck, msg.Type)
+	}
+	conn.WriteJSON(wsMessage{ID: "1", Type: wsSubscribe, Payload: json.RawMessage(`{"query":"` + query + `"}`)})
+	if msg := readTestMessage(t, conn); msg.Type != wsNext || string(msg.Payload) != `{"data":{"session":"started"}}` {
+		t.Fatalf("expected subscription result, got %s %s", msg.Type, msg.Payload)
+	}
+}
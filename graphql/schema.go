@@ -1,109 +1,216 @@
 package graphql
 
 import (
+	"context"
 	"errors"
 
 	. "github.com/graphql-go/graphql"
 )
 
 var (
-	ErrDuplicateMutation error = errors.New("Duplicate mutation")
-	ErrDuplicateQuery          = errors.New("Duplicate query")
+	ErrDuplicateMutation     error = errors.New("Duplicate mutation")
+	ErrDuplicateQuery              = errors.New("Duplicate query")
+	ErrDuplicateSubscription       = errors.New("Duplicate subscription")
 )
 
-var (
-	typeMap   map[string]Type = make(map[string]Type)
-	types     []Type          = make([]Type, 0)
-	queries   Fields          = Fields{}
-	mutations                 = Fields{}
-)
+type Registry struct {
+	typeMap       map[string]Type
+	types         []Type
+	queries       Fields
+	mutations     Fields
+	subscriptions Fields
+	errorMapper   ErrorMapper
+	// inputFields are the renamed fields of the input types, by graphql name
+	inputFields map[*InputObject]map[string]string
+	oneOfInputs map[*InputObject]struct{}
+	// typeOwners are the proto declarations of the dynamic types, by graphql
+	// name
+	typeOwners map[string]string
+}
+
+// OneOfDirective marks the input types of the oneof(s), like the oneOf
+// directive of the generated schema definition
+var OneOfDirective *Directive = NewDirective(DirectiveConfig{
+	Name:        "oneOf",
+	Description: "Exactly one field of the input object must be set.",
+	Locations:   []string{DirectiveLocationInputObject},
+})
 
-func init() {
-	RegisterType(Scalar_bytes)
-	RegisterType(Scalar_durationpb_Duration)
-	RegisterType(Scalar_emptypb_Empty)
-	RegisterType(Scalar_timestamppb_Timestamp)
-	RegisterType(Object_wrapperspb_Fixed64Value)
-	RegisterType(Object_wrapperspb_SFixed64Value)
-	RegisterType(Object_wrapperspb_SInt64Value)
-	RegisterType(Object_wrapperspb_UInt64Value)
-	RegisterType(Object_wrapperspb_BoolValue)
-	RegisterType(Object_wrapperspb_DoubleValue)
-	RegisterType(Object_wrapperspb_Fixed32Value)
-	RegisterType(Object_wrapperspb_FloatValue)
-	RegisterType(Object_wrapperspb_Int32Value)
-	RegisterType(Object_wrapperspb_Int64Value)
-	RegisterType(Object_wrapperspb_SFixed32Value)
-	RegisterType(Object_wrapperspb_SInt32Value)
-	RegisterType(Object_wrapperspb_StringValue)
-	RegisterType(Object_wrapperspb_UInt32Value)
-	RegisterType(Input_wrapperspb_BoolValue)
-	RegisterType(Input_wrapperspb_DoubleValue)
-	RegisterType(Input_wrapperspb_Fixed64Value)
-	RegisterType(Input_wrapperspb_FloatValue)
-	RegisterType(Input_wrapperspb_Int32Value)
-	RegisterType(Input_wrapperspb_SFixed64Value)
-	RegisterType(Input_wrapperspb_SInt64Value)
-	RegisterType(Input_wrapperspb_StringValue)
-	RegisterType(Input_wrapperspb_UInt64Value)
-	RegisterType(Input_wrapperspb_Fixed32Value)
-	RegisterType(Input_wrapperspb_Int64Value)
-	RegisterType(Input_wrapperspb_SFixed32Value)
-	RegisterType(Input_wrapperspb_SInt32Value)
-	RegisterType(Input_wrapperspb_UInt32Value)
+var defaultRegistry *Registry = NewRegistry()
+
+func NewRegistry() *Registry {
+	r := &Registry{
+		typeMap:       make(map[string]Type),
+		types:         make([]Type, 0),
+		queries:       Fields{},
+		mutations:     Fields{},
+		subscriptions: Fields{},
+		errorMapper:   MapStatusError,
+		inputFields:   make(map[*InputObject]map[string]string),
+		oneOfInputs:   make(map[*InputObject]struct{}),
+		typeOwners:    make(map[string]string),
+	}
+	r.RegisterType(Scalar_bytes)
+	r.RegisterType(Scalar_durationpb_Duration)
+	r.RegisterType(Scalar_emptypb_Empty)
+	r.RegisterType(Scalar_timestamppb_Timestamp)
+	r.RegisterType(Object_wrapperspb_Fixed64Value)
+	r.RegisterType(Object_wrapperspb_SFixed64Value)
+	r.RegisterType(Object_wrapperspb_SInt64Value)
+	r.RegisterType(Object_wrapperspb_UInt64Value)
+	r.RegisterType(Object_wrapperspb_BoolValue)
+	r.RegisterType(Object_wrapperspb_DoubleValue)
+	r.RegisterType(Object_wrapperspb_Fixed32Value)
+	r.RegisterType(Object_wrapperspb_FloatValue)
+	r.RegisterType(Object_wrapperspb_Int32Value)
+	r.RegisterType(Object_wrapperspb_Int64Value)
+	r.RegisterType(Object_wrapperspb_SFixed32Value)
+	r.RegisterType(Object_wrapperspb_SInt32Value)
+	r.RegisterType(Object_wrapperspb_StringValue)
+	r.RegisterType(Object_wrapperspb_UInt32Value)
+	r.RegisterType(Input_wrapperspb_BoolValue)
+	r.RegisterType(Input_wrapperspb_DoubleValue)
+	r.RegisterType(Input_wrapperspb_Fixed64Value)
+	r.RegisterType(Input_wrapperspb_FloatValue)
+	r.RegisterType(Input_wrapperspb_Int32Value)
+	r.RegisterType(Input_wrapperspb_SFixed64Value)
+	r.RegisterType(Input_wrapperspb_SInt64Value)
+	r.RegisterType(Input_wrapperspb_StringValue)
+	r.RegisterType(Input_wrapperspb_UInt64Value)
+	r.RegisterType(Input_wrapperspb_Fixed32Value)
+	r.RegisterType(Input_wrapperspb_Int64Value)
+	r.RegisterType(Input_wrapperspb_SFixed32Value)
+	r.RegisterType(Input_wrapperspb_SInt32Value)
+	r.RegisterType(Input_wrapperspb_UInt32Value)
+	return r
 }
 
-func RegisterType(newType Type) {
+// DefaultRegistry returns the registry used by the package level functions
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+func (r *Registry) RegisterType(newType Type) {
 	name := newType.Name()
-	_, exists := typeMap[name]
+	_, exists := r.typeMap[name]
+	r.typeMap[name] = newType
 	if !exists {
-		types = append(types, newType)
-		typeMap[name] = newType
+		r.types = append(r.types, newType)
 		return
 	}
-	for idx, t := range types {
+	for idx, t := range r.types {
 		if t.Name() == name {
-			types[idx] = newType
+			r.types[idx] = newType
 			return
 		}
 	}
 }
 
-func LookupType(name string) (Type, bool) {
-	for _, t := range types {
-		if t.Name() == name {
-			return t, true
-		}
-	}
-	return nil, false
+func (r *Registry) LookupType(name string) (Type, bool) {
+	t, ok := r.typeMap[name]
+	return t, ok
 }
 
-func RegisterQuery(name string, field *Field) error {
-	if _, exist := queries[name]; exist {
+func (r *Registry) RegisterQuery(name string, field *Field) error {
+	if _, exist := r.queries[name]; exist {
 		return ErrDuplicateQuery
 	}
 
-	queries[name] = field
+	r.queries[name] = field
 	return nil
 }
 
-func RegisterMutation(name string, field *Field) error {
-	if _, exist := mutations[name]; exist {
+func (r *Registry) RegisterMutation(name string, field *Field) error {
+	if _, exist := r.mutations[name]; exist {
 		return ErrDuplicateMutation
 	}
 
-	mutations[name] = field
+	r.mutations[name] = field
 	return nil
 }
 
-func GetSchema() (*Schema, error) {
-	rootQuery := ObjectConfig{Name: "RootQuery", Fields: queries}
-	rootMutation := ObjectConfig{Name: "RootMutation", Fields: mutations}
+func (r *Registry) RegisterSubscription(name string, field *Field) error {
+	if _, exist := r.subscriptions[name]; exist {
+		return ErrDuplicateSubscription
+	}
+
+	r.subscriptions[name] = field
+	return nil
+}
+
+// SetErrorMapper replaces the ErrorMapper converting the errors of upstream
+// rpc(s), MapStatusError by default
+func (r *Registry) SetErrorMapper(m ErrorMapper) {
+	r.errorMapper = m
+}
+
+// MapError converts the error of an upstream rpc with the ErrorMapper of the
+// registry
+func (r *Registry) MapError(ctx context.Context, err error) error {
+	if err == nil || r.errorMapper == nil {
+		return err
+	}
+	return r.errorMapper(ctx, err)
+}
+
+func (r *Registry) Schema() (*Schema, error) {
+	rootQuery := ObjectConfig{Name: "RootQuery", Fields: r.queries}
 	schemaConfig := SchemaConfig{
-		Query:    NewObject(rootQuery),
-		Mutation: NewObject(rootMutation),
-		Types:    types,
+		Query: NewObject(rootQuery),
+		Types: r.types,
+	}
+	if len(r.mutations) > 0 {
+		rootMutation := ObjectConfig{Name: "RootMutation", Fields: r.mutations}
+		schemaConfig.Mutation = NewObject(rootMutation)
+	}
+	if len(r.subscriptions) > 0 {
+		rootSubscription := ObjectConfig{Name: "RootSubscription", Fields: r.subscriptions}
+		schemaConfig.Subscription = NewObject(rootSubscription)
+	}
+	if len(r.oneOfInputs) > 0 {
+		directives := make([]*Directive, 0, len(SpecifiedDirectives)+1)
+		schemaConfig.Directives = append(append(directives, SpecifiedDirectives...), OneOfDirective)
 	}
 	schema, err := NewSchema(schemaConfig)
 	return &schema, err
 }
+
+func RegisterType(newType Type) {
+	defaultRegistry.RegisterType(newType)
+}
+
+func LookupType(name string) (Type, bool) {
+	return defaultRegistry.LookupType(name)
+}
+
+func RegisterQuery(name string, field *Field) error {
+	return defaultRegistry.RegisterQuery(name, field)
+}
+
+func RegisterMutation(name string, field *Field) error {
+	return defaultRegistry.RegisterMutation(name, field)
+}
+
+func RegisterSubscription(name string, field *Field) error {
+	return defaultRegistry.RegisterSubscription(name, field)
+}
+
+func SetErrorMapper(m ErrorMapper) {
+	defaultRegistry.SetErrorMapper(m)
+}
+
+func RenameInputField(t *InputObject, name, jsonName string) {
+	defaultRegistry.RenameInputField(t, name, jsonName)
+}
+
+func OneOfInput(t *InputObject) {
+	defaultRegistry.OneOfInput(t)
+}
+
+func MarshalInput(t Input, value interface{}) ([]byte, error) {
+	return defaultRegistry.MarshalInput(t, value)
+}
+
+func GetSchema() (*Schema, error) {
+	return defaultRegistry.Schema()
+}
@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"testing"
+
+	. "github.com/graphql-go/graphql"
+)
+
+func TestRegistrySchema(t *testing.T) {
+	public := NewRegistry()
+	internal := NewRegistry()
+	hello := &Field{
+		Type: String,
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			return "world", nil
+		},
+	}
+	if err := public.RegisterQuery("hello", hello); err != nil {
+		t.Fatalf("failed to register query: %s", err.Error())
+	}
+	if err := public.RegisterQuery("hello", hello); err != ErrDuplicateQuery {
+		t.Fatalf("expected %v, got %v", ErrDuplicateQuery, err)
+	}
+	if err := internal.RegisterQuery("hello", hello); err != nil {
+		t.Fatalf("failed to register query: %s", err.Error())
+	}
+	if err := internal.RegisterMutation("reset", hello); err != nil {
+		t.Fatalf("failed to register mutation: %s", err.Error())
+	}
+	publicSchema, err := public.Schema()
+	if err != nil {
+		t.Fatalf("failed to build public schema: %s", err.Error())
+	}
+	if publicSchema.MutationType() != nil {
+		t.Error("public schema must not have mutations")
+	}
+	internalSchema, err := internal.Schema()
+	if err != nil {
+		t.Fatalf("failed to build internal schema: %s", err.Error())
+	}
+	if internalSchema.MutationType() == nil {
+		t.Error("internal schema must have mutations")
+	}
+	if _, ok := public.LookupType("Timestamp"); !ok {
+		t.Error("registry must contain well known types")
+	}
+}
+
+func TestRegistrySchemaOneOf(t *testing.T) {
+	reg := NewRegistry()
+	hello := &Field{Type: String}
+	if err := reg.RegisterQuery("hello", hello); err != nil {
+		t.Fatalf("failed to register query: %s", err.Error())
+	}
+	schema, err := reg.Schema()
+	if err != nil {
+		t.Fatalf("failed to build schema: %s", err.Error())
+	}
+	if schema.Directive("oneOf") != nil {
+		t.Error("schema without oneof input must not have the oneOf directive")
+	}
+	reg.OneOfInput(NewInputObject(InputObjectConfig{
+		Name:   "SchemaOneOf",
+		Fields: InputObjectConfigFieldMap{"a": &InputObjectFieldConfig{Type: String}},
+	}))
+	if schema, err = reg.Schema(); err != nil {
+		t.Fatalf("failed to build schema: %s", err.Error())
+	}
+	if schema.Directive("oneOf") == nil || schema.Directive("skip") == nil {
+		t.Error("schema must have the oneOf directive along the specified directives")
+	}
+}
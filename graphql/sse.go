@@ -0,0 +1,121 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/graphql-go/graphql"
+)
+
+const (
+	defaultKeepAlive = 12 * time.Second
+)
+
+var (
+	ErrStreamingUnsupported error = errors.New("streaming unsupported")
+	ErrMissingQuery               = errors.New("missing query")
+)
+
+// SSEHandler serves the schema using the GraphQL over Server-Sent Events
+// protocol in "distinct connections" mode
+type SSEHandler struct {
+	Schema    *Schema
+	KeepAlive time.Duration
+}
+
+func NewSSEHandler(schema *Schema) *SSEHandler {
+	return &SSEHandler{
+		Schema:    schema,
+		KeepAlive: defaultKeepAlive,
+	}
+}
+
+func (h *SSEHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseSSERequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, ErrStreamingUnsupported.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	results := execute(ctx, h.Schema, opts)
+	keepAlive := h.KeepAlive
+	if keepAlive <= 0 {
+		keepAlive = defaultKeepAlive
+	}
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case res, more := <-results:
+			if !more {
+				fmt.Fprint(w, "event: complete\ndata:\n\n")
+				flusher.Flush()
+				return
+			}
+			data, err := json.Marshal(res)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: next\ndata: %s\n\n", data)
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ":\n\n")
+			flusher.Flush()
+		case <-ctx.Done():
+			// drain the remaining results so the executor can observe the
+			// cancelled context and release the upstream stream
+			go func() {
+				for range results {
+				}
+			}()
+			return
+		}
+	}
+}
+
+func parseSSERequest(r *http.Request) (RequestOptions, error) {
+	var opts RequestOptions
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		opts.Query = q.Get("query")
+		opts.OperationName = q.Get("operationName")
+		if variables := q.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &opts.Variables); err != nil {
+				return opts, err
+			}
+		}
+		if extensions := q.Get("extensions"); extensions != "" {
+			if err := json.Unmarshal([]byte(extensions), &opts.Extensions); err != nil {
+				return opts, err
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+			return opts, err
+		}
+	default:
+		return opts, fmt.Errorf("unsupported method: %s", r.Method)
+	}
+	if opts.Query == "" {
+		return opts, ErrMissingQuery
+	}
+	return opts, nil
+}
@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSSESubscribe(t *testing.T) {
+	srv := httptest.NewServer(NewSSEHandler(newTestSubscriptionSchema(t, nil)))
+	defer srv.Close()
+	cases := []struct {
+		name string
+		req  func() (*http.Response, error)
+		want string
+	}{
+		{
+			name: "GET subscription",
+			req: func() (*http.Response, error) {
+				return http.Get(srv.URL + "?query=" + url.QueryEscape("subscription($to: Int) { count(to: $to) }") + "&variables=" + url.QueryEscape(`{"to":2}`))
+			},
+			want: "event: next\ndata: {\"data\":{\"count\":1}}\n\n" +
+				"event: next\ndata: {\"data\":{\"count\":2}}\n\n" +
+				"event: complete\ndata:\n\n",
+		},
+		{
+			name: "POST query",
+			req: func() (*http.Response, error) {
+				return http.Post(srv.URL, "application/json", strings.NewReader(`{"query":"{ hello }"}`))
+			},
+			want: "event: next\ndata: {\"data\":{\"hello\":\"world\"}}\n\n" +
+				"event: complete\ndata:\n\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res, err := c.req()
+			if err != nil {
+				t.Fatalf("request failed: %s", err.Error())
+			}
+			defer res.Body.Close()
+			if ct := res.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+				t.Fatalf("unexpected content type: %s", ct)
+			}
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Fatalf("failed to read body: %s", err.Error())
+			}
+			if string(body) != c.want {
+				t.Errorf("expected %q, got %q", c.want, string(body))
+			}
+		})
+	}
+}
+
+func TestSSECancel(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(NewSSEHandler(newTestSubscriptionSchema(t, done)))
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"?query="+url.QueryEscape("subscription { count }"), nil)
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatalf("request failed: %s", err.Error())
+	}
+	buf := make([]byte, 16)
+	res.Body.Read(buf)
+	cancel()
+	res.Body.Close()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription context was not cancelled")
+	}
+}
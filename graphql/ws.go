@@ -0,0 +1,215 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/graphql-go/graphql"
+)
+
+const (
+	wsProtocol = "graphql-transport-ws"
+
+	wsConnectionInit = "connection_init"
+	wsConnectionAck  = "connection_ack"
+	wsPing           = "ping"
+	wsPong           = "pong"
+	wsSubscribe      = "subscribe"
+	wsNext           = "next"
+	wsError          = "error"
+	wsComplete       = "complete"
+
+	wsCloseBadRequest          = 4400
+	wsCloseUnauthorized        = 4401
+	wsCloseProtocolUnsupported = 4406
+	wsCloseInitTimeout         = 4408
+	wsCloseSubscriberExists    = 4409
+	wsCloseTooManyInit         = 4429
+
+	defaultInitTimeout = 3 * time.Second
+)
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// WebsocketHandler serves the schema over the `graphql-transport-ws` protocol
+type WebsocketHandler struct {
+	Schema      *Schema
+	Upgrader    websocket.Upgrader
+	InitTimeout time.Duration
+}
+
+func NewWebsocketHandler(schema *Schema) *WebsocketHandler {
+	return &WebsocketHandler{
+		Schema: schema,
+		Upgrader: websocket.Upgrader{
+			Subprotocols: []string{wsProtocol},
+		},
+		InitTimeout: defaultInitTimeout,
+	}
+}
+
+func (h *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	s := &wsSession{
+		conn:   conn,
+		schema: h.Schema,
+		ctx:    ctx,
+		subs:   make(map[string]context.CancelFunc),
+	}
+	defer conn.Close()
+	if conn.Subprotocol() != wsProtocol {
+		s.close(wsCloseProtocolUnsupported, "Unsupported WebSocket subprotocol")
+		return
+	}
+	timeout := h.InitTimeout
+	if timeout <= 0 {
+		timeout = defaultInitTimeout
+	}
+	initTimer := time.AfterFunc(timeout, func() {
+		if !s.isAcknowledged() {
+			s.close(wsCloseInitTimeout, "Connection initialisation timeout")
+		}
+	})
+	defer initTimer.Stop()
+	s.serve()
+}
+
+type wsSession struct {
+	conn    *websocket.Conn
+	schema  *Schema
+	ctx     context.Context
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	acked   bool
+	subs    map[string]context.CancelFunc
+}
+
+func (s *wsSession) isAcknowledged() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.acked
+}
+
+func (s *wsSession) serve() {
+	for {
+		var msg wsMessage
+		err := s.conn.ReadJSON(&msg)
+		if err != nil {
+			if _, ok := err.(*websocket.CloseError); !ok {
+				s.close(wsCloseBadRequest, "Invalid message received")
+			}
+			return
+		}
+		switch msg.Type {
+		case wsConnectionInit:
+			s.mu.Lock()
+			acked := s.acked
+			s.acked = true
+			s.mu.Unlock()
+			if acked {
+				s.close(wsCloseTooManyInit, "Too many initialisation requests")
+				return
+			}
+			s.send(wsMessage{Type: wsConnectionAck})
+		case wsPing:
+			s.send(wsMessage{Type: wsPong})
+		case wsPong:
+		case wsSubscribe:
+			if !s.isAcknowledged() {
+				s.close(wsCloseUnauthorized, "Unauthorized")
+				return
+			}
+			var opts RequestOptions
+			if msg.ID == "" || json.Unmarshal(msg.Payload, &opts) != nil {
+				s.close(wsCloseBadRequest, "Invalid message received")
+				return
+			}
+			if !s.subscribe(msg.ID, opts) {
+				s.close(wsCloseSubscriberExists, "Subscriber for "+msg.ID+" already exists")
+				return
+			}
+		case wsComplete:
+			s.mu.Lock()
+			if cancel, ok := s.subs[msg.ID]; ok {
+				cancel()
+				delete(s.subs, msg.ID)
+			}
+			s.mu.Unlock()
+		default:
+			s.close(wsCloseBadRequest, "Invalid message received")
+			return
+		}
+	}
+}
+
+func (s *wsSession) subscribe(id string, opts RequestOptions) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.subs[id]; exists {
+		return false
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.subs[id] = cancel
+	go func() {
+		first := true
+		failed := false
+		// results are always drained so the executor can observe the
+		// cancelled context and release the upstream stream
+		for res := range execute(ctx, s.schema, opts) {
+			if ctx.Err() != nil || failed {
+				continue
+			}
+			if first && res.Data == nil && res.HasErrors() {
+				failed = true
+				s.sendPayload(id, wsError, res.Errors)
+				continue
+			}
+			first = false
+			s.sendPayload(id, wsNext, res)
+		}
+		s.mu.Lock()
+		_, active := s.subs[id]
+		delete(s.subs, id)
+		s.mu.Unlock()
+		if active && !failed && ctx.Err() == nil {
+			s.send(wsMessage{ID: id, Type: wsComplete})
+		}
+		cancel()
+	}()
+	return true
+}
+
+func (s *wsSession) sendPayload(id, typ string, payload interface{}) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	s.send(wsMessage{ID: id, Type: typ, Payload: b})
+}
+
+func (s *wsSession) send(msg wsMessage) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.conn.WriteJSON(msg)
+}
+
+func (s *wsSession) close(code int, reason string) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	deadline := time.Now().Add(time.Second)
+	s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), deadline)
+	s.conn.Close()
+}
@@ -0,0 +1,181 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/graphql-go/graphql"
+)
+
+func newTestSubscriptionSchema(t *testing.T, done chan struct{}) *Schema {
+	schema, err := NewSchema(SchemaConfig{
+		Query: NewObject(ObjectConfig{
+			Name: "RootQuery",
+			Fields: Fields{
+				"hello": &Field{
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return "world", nil
+					},
+				},
+			},
+		}),
+		Subscription: NewObject(ObjectConfig{
+			Name: "RootSubscription",
+			Fields: Fields{
+				"count": &Field{
+					Type: Int,
+					Args: FieldConfigArgument{
+						"to": &ArgumentConfig{Type: Int},
+					},
+					Subscribe: func(p ResolveParams) (interface{}, error) {
+						to, _ := p.Args["to"].(int)
+						ch := make(chan interface{})
+						go func() {
+							defer close(ch)
+							for i := 1; to == 0 || i <= to; i++ {
+								select {
+								case ch <- i:
+								case <-p.Context.Done():
+									if done != nil {
+										close(done)
+									}
+									return
+								}
+							}
+						}()
+						return ch, nil
+					},
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return p.Source, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to create schema: %s", err.Error())
+	}
+	return &schema
+}
+
+func dialTestWebsocket(t *testing.T, h http.Handler) *websocket.Conn {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	dialer := websocket.Dialer{Subprotocols: []string{wsProtocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to dial websocket: %s", err.Error())
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+func readTestMessage(t *testing.T, conn *websocket.Conn) wsMessage {
+	var msg wsMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("failed to read message: %s", err.Error())
+	}
+	return msg
+}
+
+func TestWebsocketSubscribe(t *testing.T) {
+	conn := dialTestWebsocket(t, NewWebsocketHandler(newTestSubscriptionSchema(t, nil)))
+	conn.WriteJSON(wsMessage{Type: wsConnectionInit})
+	if msg := readTestMessage(t, conn); msg.Type != wsConnectionAck {
+		t.Fatalf("expected %s, got %s", wsConnectionAck, msg.Type)
+	}
+	conn.WriteJSON(wsMessage{Type: wsPing})
+	if msg := readTestMessage(t, conn); msg.Type != wsPong {
+		t.Fatalf("expected %s, got %s", wsPong, msg.Type)
+	}
+	conn.WriteJSON(wsMessage{ID: "1", Type: wsSubscribe, Payload: json.RawMessage(`{"query":"subscription { count(to: 3) }"}`)})
+	for i := 1; i <= 3; i++ {
+		msg := readTestMessage(t, conn)
+		want := `{"data":{"count":` + strconv.Itoa(i) + `}}`
+		if msg.Type != wsNext || msg.ID != "1" || string(msg.Payload) != want {
+			t.Fatalf("expected next %s, got %s %s", want, msg.Type, msg.Payload)
+		}
+	}
+	if msg := readTestMessage(t, conn); msg.Type != wsComplete || msg.ID != "1" {
+		t.Fatalf("expected complete, got %s", msg.Type)
+	}
+	conn.WriteJSON(wsMessage{ID: "2", Type: wsSubscribe, Payload: json.RawMessage(`{"query":"{ hello }"}`)})
+	if msg := readTestMessage(t, conn); msg.Type != wsNext || string(msg.Payload) != `{"data":{"hello":"world"}}` {
+		t.Fatalf("expected query result, got %s %s", msg.Type, msg.Payload)
+	}
+	if msg := readTestMessage(t, conn); msg.Type != wsComplete || msg.ID != "2" {
+		t.Fatalf("expected complete, got %s", msg.Type)
+	}
+	conn.WriteJSON(wsMessage{ID: "3", Type: wsSubscribe, Payload: json.RawMessage(`{"query":"subscription { unknown }"}`)})
+	if msg := readTestMessage(t, conn); msg.Type != wsError || msg.ID != "3" {
+		t.Fatalf("expected error, got %s", msg.Type)
+	}
+}
+
+func TestWebsocketClientComplete(t *testing.T) {
+	done := make(chan struct{})
+	conn := dialTestWebsocket(t, NewWebsocketHandler(newTestSubscriptionSchema(t, done)))
+	conn.WriteJSON(wsMessage{Type: wsConnectionInit})
+	readTestMessage(t, conn)
+	conn.WriteJSON(wsMessage{ID: "1", Type: wsSubscribe, Payload: json.RawMessage(`{"query":"subscription { count }"}`)})
+	if msg := readTestMessage(t, conn); msg.Type != wsNext {
+		t.Fatalf("expected next, got %s", msg.Type)
+	}
+	conn.WriteJSON(wsMessage{ID: "1", Type: wsComplete})
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription context was not cancelled")
+	}
+}
+
+func TestWebsocketProtocolErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		messages []wsMessage
+		wantCode int
+	}{
+		{
+			name:     "subscribe before init",
+			messages: []wsMessage{{ID: "1", Type: wsSubscribe, Payload: json.RawMessage(`{"query":"{ hello }"}`)}},
+			wantCode: wsCloseUnauthorized,
+		},
+		{
+			name:     "too many init",
+			messages: []wsMessage{{Type: wsConnectionInit}, {Type: wsConnectionInit}},
+			wantCode: wsCloseTooManyInit,
+		},
+		{
+			name:     "unknown message",
+			messages: []wsMessage{{Type: "unknown"}},
+			wantCode: wsCloseBadRequest,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			conn := dialTestWebsocket(t, NewWebsocketHandler(newTestSubscriptionSchema(t, nil)))
+			for _, msg := range c.messages {
+				conn.WriteJSON(msg)
+			}
+			for {
+				var msg wsMessage
+				err := conn.ReadJSON(&msg)
+				if err == nil {
+					continue
+				}
+				if !websocket.IsCloseError(err, c.wantCode) {
+					t.Fatalf("expected close code %d, got %v", c.wantCode, err)
+				}
+				return
+			}
+		})
+	}
+}
@@ -4,11 +4,6 @@ import (
 	"github.com/ncrypthic/graphql-grpc-edge/generator"
 )
 
-type option struct {
-	SourcePath      string
-	DestinationPath string
-}
-
 func main() {
 	generator.Generate()
 }